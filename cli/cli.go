@@ -10,9 +10,11 @@ package cli
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/essentialkaos/ek/v13/fmtc"
 	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/knf"
 	"github.com/essentialkaos/ek/v13/options"
 	"github.com/essentialkaos/ek/v13/pager"
 	"github.com/essentialkaos/ek/v13/support"
@@ -28,9 +30,13 @@ import (
 
 	term "github.com/essentialkaos/ek/v13/terminal"
 
+	"github.com/essentialkaos/shdoc/finder"
+	"github.com/essentialkaos/shdoc/lint"
 	"github.com/essentialkaos/shdoc/parser"
+	"github.com/essentialkaos/shdoc/render/graph"
 	"github.com/essentialkaos/shdoc/render/template"
 	"github.com/essentialkaos/shdoc/render/terminal"
+	"github.com/essentialkaos/shdoc/script"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -41,10 +47,24 @@ const (
 	DESC = "Tool for viewing and exporting docs for shell scripts"
 )
 
+const (
+	CMD_LINT  = "lint"
+	CMD_GRAPH = "graph"
+)
+
 const (
 	OPT_OUTPUT   = "o:output"
 	OPT_TEMPLATE = "t:template"
 	OPT_NAME     = "n:name"
+	OPT_CONFIG   = "c:config"
+	OPT_TYPES    = "T:types"
+	OPT_FORMAT   = "f:format"
+	OPT_DIALECT  = "D:dialect"
+	OPT_AUTOLOAD = "A:autoload"
+	OPT_FOLLOW   = "F:follow"
+	OPT_SOURCES  = "S:sources"
+	OPT_INCLUDE  = "i:include"
+	OPT_EXCLUDE  = "e:exclude"
 	OPT_NO_PAGER = "np:no-pager"
 	OPT_NO_COLOR = "nc:no-color"
 	OPT_HELP     = "h:help"
@@ -61,6 +81,15 @@ var optMap = options.Map{
 	OPT_OUTPUT:   {},
 	OPT_TEMPLATE: {Value: "html"},
 	OPT_NAME:     {},
+	OPT_CONFIG:   {},
+	OPT_TYPES:    {},
+	OPT_FORMAT:   {Value: graph.FORMAT_DOT},
+	OPT_DIALECT:  {Value: parser.DIALECT_AUTO},
+	OPT_AUTOLOAD: {Type: options.BOOL},
+	OPT_FOLLOW:   {Type: options.BOOL},
+	OPT_SOURCES:  {Type: options.BOOL},
+	OPT_INCLUDE:  {Mergeble: true},
+	OPT_EXCLUDE:  {Mergeble: true},
 	OPT_NO_PAGER: {Type: options.BOOL},
 	OPT_NO_COLOR: {Type: options.BOOL},
 	OPT_HELP:     {Type: options.BOOL},
@@ -108,10 +137,33 @@ func Run(gitRev string, gomod []byte) {
 		os.Exit(0)
 	}
 
-	err := readDocs(
-		args.Get(0).Clean().String(),
-		args.Get(1).String(),
-	)
+	if options.Has(OPT_TYPES) {
+		err := readTypes(options.GetS(OPT_TYPES))
+
+		if err != nil {
+			term.Error("Can't read custom types: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	_, err := parser.GetDialect(options.GetS(OPT_DIALECT))
+
+	if err != nil {
+		term.Error(err)
+		os.Exit(1)
+	}
+
+	switch args.Get(0).String() {
+	case CMD_LINT:
+		os.Exit(lintScripts(args.Strings()[1:]))
+	case CMD_GRAPH:
+		err = renderGraph(args.Get(1).Clean().String())
+	default:
+		err = readDocs(
+			args.Get(0).Clean().String(),
+			args.Get(1).String(),
+		)
+	}
 
 	if err != nil {
 		term.Error(err)
@@ -137,18 +189,21 @@ func configureUI() {
 
 // readDocs reads the file and prints documentation from it
 func readDocs(file string, pattern string) error {
-	err := fsutil.ValidatePerms("FRS", file)
+	if fsutil.IsDir(file) && !options.GetB(OPT_AUTOLOAD) {
+		return readDir(file)
+	}
+
+	err := fsutil.ValidatePerms(getScriptPerms(), file)
 
 	if err != nil {
 		return err
 	}
 
-	doc, errs := parser.Parse(file)
+	doc, diags := parseScript(file)
 
-	if !errs.IsEmpty() {
-		term.Error("Shell script documentation parsing errors:")
-		term.Error(errs.Error(" - "))
-		fmtc.NewLine()
+	printDiagnostics(filterDiagnostics(diags, parser.SEVERITY_WARNING))
+
+	if doc == nil {
 		return fmt.Errorf("Can't parse script documentation")
 	}
 
@@ -182,6 +237,263 @@ func readDocs(file string, pattern string) error {
 	return err
 }
 
+// readDir reads all scripts in directory and renders documentation for them
+func readDir(dir string) error {
+	if !options.Has(OPT_OUTPUT) {
+		return fmt.Errorf("You must define output directory for rendering documentation for directory")
+	}
+
+	err := fsutil.ValidatePerms("DRX", dir)
+
+	if err != nil {
+		return err
+	}
+
+	files, err := finder.Find(dir, options.Split(OPT_INCLUDE), options.Split(OPT_EXCLUDE))
+
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("Directory %s doesn't contain any shell scripts", dir)
+	}
+
+	var diags parser.Diagnostics
+
+	index := &script.Index{Title: filepath.Base(dir)}
+
+	if options.GetS(OPT_NAME) != "" {
+		index.Title = options.GetS(OPT_NAME)
+	}
+
+	for _, file := range files {
+		doc, fileDiags := parser.Parse(filepath.Join(dir, file), getParseOptions())
+
+		diags = append(diags, fileDiags...)
+
+		if !doc.IsValid() {
+			index.Undocumented = append(index.Undocumented, file)
+			continue
+		}
+
+		index.Scripts = append(index.Scripts, &script.IndexEntry{Path: file, Document: doc})
+	}
+
+	printDiagnostics(filterDiagnostics(diags, parser.SEVERITY_WARNING))
+
+	err = os.MkdirAll(options.GetS(OPT_OUTPUT), 0755)
+
+	if err != nil {
+		return err
+	}
+
+	return template.RenderIndex(
+		index,
+		options.GetS(OPT_TEMPLATE),
+		options.GetS(OPT_OUTPUT),
+	)
+}
+
+// parseScript parses script and all sourced scripts if follow mode is enabled
+// or directory with autoloaded functions if autoload mode is enabled
+func parseScript(file string) (*script.Document, parser.Diagnostics) {
+	if options.GetB(OPT_AUTOLOAD) {
+		return parser.ParseAutoload(file, getParseOptions())
+	}
+
+	if !options.GetB(OPT_FOLLOW) {
+		return parser.Parse(file, getParseOptions())
+	}
+
+	set, diags := parser.ParseSet(file, getParseOptions())
+
+	return set.Merge(), diags
+}
+
+// getParseOptions returns options for parser
+func getParseOptions() *parser.Options {
+	// Name of dialect is validated before processing command
+	d, _ := parser.GetDialect(options.GetS(OPT_DIALECT))
+
+	return &parser.Options{Dialect: d}
+}
+
+// renderGraph renders methods call graph
+func renderGraph(file string) error {
+	if file == "." {
+		return fmt.Errorf("You must define script for rendering call graph")
+	}
+
+	err := fsutil.ValidatePerms(getScriptPerms(), file)
+
+	if err != nil {
+		return err
+	}
+
+	if options.GetB(OPT_SOURCES) {
+		set, diags := parser.ParseSet(file, getParseOptions())
+
+		printDiagnostics(filterDiagnostics(diags, parser.SEVERITY_WARNING))
+
+		if set.Root() == nil {
+			return fmt.Errorf("Can't parse script %s", file)
+		}
+
+		return graph.RenderIncludes(set, options.GetS(OPT_FORMAT), options.GetS(OPT_OUTPUT))
+	}
+
+	doc, diags := parseScript(file)
+
+	printDiagnostics(filterDiagnostics(diags, parser.SEVERITY_WARNING))
+
+	if !doc.HasMethods() {
+		return fmt.Errorf("File %s doesn't contains any documented methods", file)
+	}
+
+	if options.GetS(OPT_NAME) != "" {
+		doc.Title = options.GetS(OPT_NAME)
+	}
+
+	return graph.Render(doc, options.GetS(OPT_FORMAT), options.GetS(OPT_OUTPUT))
+}
+
+// getScriptPerms returns permissions required for parsed script or directory
+// with autoloaded functions
+func getScriptPerms() string {
+	if options.GetB(OPT_AUTOLOAD) {
+		return "DRX"
+	}
+
+	return "FRS"
+}
+
+// readTypes registers custom types from section "types" of given file (name
+// of type as property name and color as value)
+func readTypes(file string) error {
+	cfg, err := knf.Read(file)
+
+	if err != nil {
+		return err
+	}
+
+	for _, name := range cfg.Props("types") {
+		_, err = script.RegisterType(name, cfg.GetS(knf.Q("types", name)))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lintScripts checks documentation in given scripts and returns exit code
+func lintScripts(files []string) int {
+	if len(files) == 0 {
+		term.Error("You must define at least one script to check")
+		return lint.EXIT_ERRORS
+	}
+
+	config := lint.DefaultConfig()
+
+	if options.Has(OPT_CONFIG) {
+		var err error
+
+		config, err = lint.ReadConfig(options.GetS(OPT_CONFIG))
+
+		if err != nil {
+			term.Error("Can't read lint configuration: %v", err)
+			return lint.EXIT_ERRORS
+		}
+	}
+
+	config.Dialect = getParseOptions().Dialect
+
+	var diags parser.Diagnostics
+
+	for _, file := range files {
+		diags = append(diags, lint.Check(file, config)...)
+	}
+
+	printDiagnostics(diags)
+	printLintSummary(diags)
+
+	return lint.GetExitCode(diags)
+}
+
+// printLintSummary prints number of findings for each severity
+func printLintSummary(diags parser.Diagnostics) {
+	var errs, warns, notices int
+
+	for _, d := range diags {
+		switch d.Severity {
+		case parser.SEVERITY_ERROR:
+			errs++
+		case parser.SEVERITY_WARNING:
+			warns++
+		default:
+			notices++
+		}
+	}
+
+	if diags.IsEmpty() {
+		fmtc.Fprintfn(os.Stderr, "{g}No problems found{!}")
+		return
+	}
+
+	fmtc.Fprintfn(
+		os.Stderr, "{*}%d problems{!} {s}(%d errors, %d warnings, %d notices){!}",
+		len(diags), errs, warns, notices,
+	)
+}
+
+// filterDiagnostics returns diagnostics with severity greater or equal to
+// given one
+func filterDiagnostics(diags parser.Diagnostics, minSeverity parser.Severity) parser.Diagnostics {
+	var result parser.Diagnostics
+
+	for _, d := range diags {
+		if d.Severity >= minSeverity {
+			result = append(result, d)
+		}
+	}
+
+	return result
+}
+
+// printDiagnostics prints parsing diagnostics in compiler style
+func printDiagnostics(diags parser.Diagnostics) {
+	if diags.IsEmpty() {
+		return
+	}
+
+	for _, d := range diags {
+		color := "{y}"
+
+		switch d.Severity {
+		case parser.SEVERITY_ERROR:
+			color = "{r}"
+		case parser.SEVERITY_NOTICE:
+			color = "{c}"
+		}
+
+		if d.Line == 0 {
+			fmtc.Fprintfn(
+				os.Stderr, "{*}%s:{!} "+color+"%s:{!} %s {s-}[%s]{!}",
+				d.File, d.Severity, d.Message, d.Rule,
+			)
+		} else {
+			fmtc.Fprintfn(
+				os.Stderr, "{*}%s:%d:%d:{!} "+color+"%s:{!} %s {s-}[%s]{!}",
+				d.File, d.Line, d.Column, d.Severity, d.Message, d.Rule,
+			)
+		}
+	}
+
+	fmtc.Fprintln(os.Stderr)
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // printCompletion prints completion for given shell
@@ -209,11 +521,20 @@ func printMan() {
 
 // genUsage generates usage info
 func genUsage() *usage.Info {
-	info := usage.NewInfo("", "script")
+	info := usage.NewInfo("", "script|dir")
 
 	info.AddOption(OPT_OUTPUT, "Path to output file", "file")
 	info.AddOption(OPT_TEMPLATE, "Name of template", "name")
 	info.AddOption(OPT_NAME, "Overwrite default name", "name")
+	info.AddOption(OPT_CONFIG, "Path to lint configuration file", "file")
+	info.AddOption(OPT_TYPES, "Path to file with custom types", "file")
+	info.AddOption(OPT_FORMAT, "Graph format {s-}(dot/mermaid){!}", "format")
+	info.AddOption(OPT_DIALECT, "Comments dialect {s-}(auto/default/annotated/google){!}", "name")
+	info.AddOption(OPT_AUTOLOAD, "Parse directory with autoloaded functions")
+	info.AddOption(OPT_FOLLOW, "Parse scripts sourced by script")
+	info.AddOption(OPT_SOURCES, "Render graph of sourced scripts instead of call graph")
+	info.AddOption(OPT_INCLUDE, "Glob pattern for scripts to document in directory", "glob")
+	info.AddOption(OPT_EXCLUDE, "Glob pattern for scripts and directories to skip", "glob")
 	info.AddOption(OPT_NO_PAGER, "Disable pager for long output")
 	info.AddOption(OPT_NO_COLOR, "Disable colors in output")
 	info.AddOption(OPT_HELP, "Show this help message")
@@ -239,6 +560,36 @@ func genUsage() *usage.Info {
 		"Parse shell script and show documentation for some constant, variable or method",
 	)
 
+	info.AddExample(
+		"lint -c lint.knf script.sh lib.sh",
+		"Check documentation quality in shell scripts",
+	)
+
+	info.AddExample(
+		"scripts/ -o site/ -e 'test/*'",
+		"Parse all shell scripts in directory and render documentation with index page",
+	)
+
+	info.AddExample(
+		"graph -f mermaid -o calls.mmd script.sh",
+		"Export methods call graph in Mermaid format",
+	)
+
+	info.AddExample(
+		"script.sh -F -t markdown -o my_script.md",
+		"Parse shell script with all sourced scripts and render documentation to markdown file",
+	)
+
+	info.AddExample(
+		"graph -S script.sh",
+		"Export graph of sourced scripts in DOT format",
+	)
+
+	info.AddExample(
+		"functions/ -A -t markdown -o functions.md",
+		"Parse directory with autoloaded zsh functions and render documentation to markdown file",
+	)
+
 	return info
 }
 
@@ -0,0 +1,129 @@
+package finder
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Extensions is a slice with extensions of shell scripts
+var Extensions = []string{".sh", ".bash", ".zsh", ".ksh"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var shebangRegExp = regexp.MustCompile(`^#![ \t]*(?:/usr)?(?:/local)?/bin/(?:env[ \t]+)?(?:ba|z|k|mk)?sh(?:[ \t]|$)`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Find recursively searches shell scripts in given directory and returns
+// slice with paths relative to the directory
+//
+// Scripts are detected by extension or by shebang. Include and exclude
+// patterns are matched against relative path and name of file.
+func Find(dir string, include, exclude []string) ([]string, error) {
+	var result []string
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == dir {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(dir, path)
+
+		if d.IsDir() {
+			if strings.HasPrefix(d.Name(), ".") || matchAny(exclude, rel) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.Type().IsRegular() || matchAny(exclude, rel) {
+			return nil
+		}
+
+		if len(include) != 0 && !matchAny(include, rel) {
+			return nil
+		}
+
+		if IsScript(path) {
+			result = append(result, rel)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(result)
+
+	return result, nil
+}
+
+// IsScript returns true if given file is a shell script
+func IsScript(file string) bool {
+	ext := filepath.Ext(file)
+
+	if slices.Contains(Extensions, ext) {
+		return true
+	}
+
+	// Files with other extensions can't be shell scripts
+	if ext != "" {
+		return false
+	}
+
+	return hasShellShebang(file)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// hasShellShebang returns true if the first line of given file is shell shebang
+func hasShellShebang(file string) bool {
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return false
+	}
+
+	defer fd.Close()
+
+	r := bufio.NewReader(fd)
+	line, _ := r.ReadString('\n')
+
+	return shebangRegExp.MatchString(strings.TrimRight(line, "\r\n"))
+}
+
+// matchAny returns true if path or name of file matches any of given patterns
+func matchAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, path); ok {
+			return true
+		}
+
+		if ok, _ := filepath.Match(pattern, filepath.Base(path)); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,106 @@
+package finder
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+type FinderSuite struct {
+	TmpDir string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&FinderSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *FinderSuite) SetUpSuite(c *C) {
+	s.TmpDir = c.MkDir()
+
+	files := map[string]string{
+		"main.sh":           "echo 1",
+		"tool":              "#!/usr/bin/env bash\necho 1",
+		"tool.py":           "#!/bin/bash\necho 1",
+		"README":            "Readme",
+		"lib/net.bash":      "echo 1",
+		"lib/prompt.zsh":    "echo 1",
+		"ktool":             "#!/usr/bin/env ksh\necho 1",
+		"ztool":             "#!/bin/zsh -f\necho 1",
+		"lib/util":          "#!/bin/sh\necho 1",
+		"lib/test/t1.sh":    "echo 1",
+		"vendor/lib.sh":     "echo 1",
+		".git/hooks/pre.sh": "echo 1",
+	}
+
+	for name, data := range files {
+		path := s.TmpDir + "/" + name
+
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+
+		if err != nil {
+			c.Fatal(err.Error())
+		}
+
+		err = os.WriteFile(path, []byte(data), 0644)
+
+		if err != nil {
+			c.Fatal(err.Error())
+		}
+	}
+}
+
+func (s *FinderSuite) TestFind(c *C) {
+	files, err := Find(s.TmpDir, nil, nil)
+
+	c.Assert(err, IsNil)
+	c.Assert(files, DeepEquals, []string{
+		"ktool", "lib/net.bash", "lib/prompt.zsh", "lib/test/t1.sh", "lib/util",
+		"main.sh", "tool", "vendor/lib.sh", "ztool",
+	})
+
+	files, err = Find(s.TmpDir, nil, []string{"vendor", "test"})
+
+	c.Assert(err, IsNil)
+	c.Assert(files, DeepEquals, []string{
+		"ktool", "lib/net.bash", "lib/prompt.zsh", "lib/util", "main.sh", "tool", "ztool",
+	})
+
+	files, err = Find(s.TmpDir, []string{"*.sh", "lib/*"}, []string{"t1.sh"})
+
+	c.Assert(err, IsNil)
+	c.Assert(files, DeepEquals, []string{
+		"lib/net.bash", "lib/prompt.zsh", "lib/util", "main.sh", "vendor/lib.sh",
+	})
+
+	_, err = Find(s.TmpDir+"/unknown", nil, nil)
+
+	c.Assert(err, NotNil)
+}
+
+func (s *FinderSuite) TestIsScript(c *C) {
+	c.Assert(IsScript(s.TmpDir+"/main.sh"), Equals, true)
+	c.Assert(IsScript(s.TmpDir+"/tool"), Equals, true)
+	c.Assert(IsScript(s.TmpDir+"/lib/prompt.zsh"), Equals, true)
+	c.Assert(IsScript(s.TmpDir+"/ktool"), Equals, true)
+	c.Assert(IsScript(s.TmpDir+"/ztool"), Equals, true)
+	c.Assert(IsScript(s.TmpDir+"/tool.py"), Equals, false)
+	c.Assert(IsScript(s.TmpDir+"/README"), Equals, false)
+	c.Assert(IsScript(s.TmpDir+"/unknown"), Equals, false)
+}
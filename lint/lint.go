@@ -0,0 +1,377 @@
+package lint
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/knf"
+
+	"github.com/essentialkaos/shdoc/parser"
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Rule IDs
+const (
+	RULE_ARGUMENT_ORDER = "argument-order"
+	RULE_UNKNOWN_CALL   = "unknown-call"
+)
+
+// Exit codes
+const (
+	EXIT_CLEAN    = 0
+	EXIT_WARNINGS = 1
+	EXIT_ERRORS   = 2
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Config contains lint configuration
+type Config struct {
+	// Rules contains severity overrides for rules, rules with 0 severity are
+	// disabled
+	Rules map[string]parser.Severity
+
+	// Commands contains names of external commands which can be called in
+	// examples in addition to common commands
+	Commands []string
+
+	// LookupPath enables search of commands called in examples in PATH (result
+	// depends on the system where lint is run)
+	LookupPath bool
+
+	// Dialect is dialect of comments in checked scripts (auto-detected for
+	// every script if nil)
+	Dialect parser.Dialect
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Rules is a slice with IDs of all supported rules
+var Rules = []string{
+	parser.RULE_OPEN_ERROR,
+	parser.RULE_SCAN_ERROR,
+	parser.RULE_MALFORMED_ARGUMENT,
+	parser.RULE_DUPLICATE_ARGUMENT,
+	parser.RULE_EMPTY_EXAMPLE,
+	parser.RULE_UNKNOWN_TYPE,
+	parser.RULE_MISSING_DOCS,
+	parser.RULE_MISSING_DESC,
+	parser.RULE_UNDOCUMENTED_ARGUMENT,
+	parser.RULE_UNUSED_ARGUMENT,
+	parser.RULE_MISSING_WILDCARD,
+	parser.RULE_DYNAMIC_SOURCE,
+	parser.RULE_MISSING_SOURCE,
+	parser.RULE_UNKNOWN_TAG,
+	parser.RULE_INVALID_DEFAULT,
+	parser.RULE_INVALID_CODE,
+	parser.RULE_UNKNOWN_REFERENCE,
+	RULE_ARGUMENT_ORDER,
+	RULE_UNKNOWN_CALL,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// shellBuiltins contains shell builtins
+var shellBuiltins = []string{
+	".", ":", "[", "alias", "bg", "bind", "break", "builtin", "caller", "cd",
+	"command", "compgen", "complete", "compopt", "continue", "declare", "dirs",
+	"disown", "echo", "enable", "eval", "exec", "exit", "export", "false", "fc",
+	"fg", "getopts", "hash", "help", "history", "jobs", "kill", "let", "local",
+	"logout", "mapfile", "popd", "printf", "pushd", "pwd", "read", "readarray",
+	"readonly", "return", "set", "shift", "shopt", "source", "suspend", "test",
+	"times", "trap", "true", "type", "typeset", "ulimit", "umask", "unalias",
+	"unset", "wait",
+
+	// zsh and ksh builtins
+	"autoload", "emulate", "float", "integer", "print", "setopt", "unsetopt",
+	"whence", "zmodload",
+}
+
+// commonCommands contains common external commands which can be called in
+// examples without configuration
+var commonCommands = []string{
+	"awk", "base64", "basename", "bash", "cat", "chmod", "chown", "cmp", "cp",
+	"curl", "cut", "date", "dd", "df", "diff", "dirname", "du", "env", "expr",
+	"file", "find", "grep", "gzip", "head", "hostname", "id", "install", "kill",
+	"less", "ln", "ls", "md5sum", "mkdir", "mktemp", "more", "mv", "nc", "od",
+	"paste", "ping", "ps", "readlink", "realpath", "rm", "rmdir", "sed", "seq",
+	"sh", "sha256sum", "sleep", "sort", "ssh", "stat", "tail", "tar", "tee",
+	"timeout", "touch", "tr", "uname", "uniq", "wc", "wget", "which", "whoami",
+	"xargs", "zsh",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DefaultConfig returns default lint configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Rules: map[string]parser.Severity{
+			parser.RULE_MISSING_DOCS: parser.SEVERITY_WARNING,
+		},
+	}
+}
+
+// ReadConfig reads lint configuration from KNF file
+//
+// Rules configured in section "rules", value of every property must be one of:
+// on, off, true, false, notice, warning or error.
+//
+// Examples can call methods defined in script and scripts sourced by it, shell
+// builtins and common external commands (cat, grep, sed…). Section "commands"
+// contains list of other allowed external commands (property "allow") and
+// flag for search of commands in PATH (property "lookup-path", disabled by
+// default because result depends on the system where lint is run).
+func ReadConfig(file string) (*Config, error) {
+	cfg, err := knf.Read(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	config := DefaultConfig()
+
+	for _, rule := range cfg.Props("rules") {
+		if !slices.Contains(Rules, rule) {
+			return nil, fmt.Errorf("Unknown rule %q", rule)
+		}
+
+		value := strings.ToLower(cfg.GetS(knf.Q("rules", rule)))
+
+		switch value {
+		case "on", "true", "yes":
+			delete(config.Rules, rule)
+		case "off", "false", "no":
+			config.Rules[rule] = 0
+		case "notice":
+			config.Rules[rule] = parser.SEVERITY_NOTICE
+		case "warning":
+			config.Rules[rule] = parser.SEVERITY_WARNING
+		case "error":
+			config.Rules[rule] = parser.SEVERITY_ERROR
+		default:
+			return nil, fmt.Errorf("Unsupported value %q for rule %q", value, rule)
+		}
+	}
+
+	config.Commands = strings.Fields(strings.ReplaceAll(cfg.GetS(knf.Q("commands", "allow")), ",", " "))
+	config.LookupPath = cfg.GetB(knf.Q("commands", "lookup-path"))
+
+	return config, nil
+}
+
+// Check parses given script and returns slice with findings
+func Check(file string, config *Config) parser.Diagnostics {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	opts := &parser.Options{Dialect: config.Dialect}
+	doc, diags := parser.Parse(file, opts)
+
+	if doc != nil {
+		diags = append(diags, checkArgumentsOrder(doc)...)
+		diags = append(diags, checkExamples(doc, readLines(file), getDefinedMethods(file, opts), config)...)
+	}
+
+	var result parser.Diagnostics
+
+	for _, d := range diags {
+		severity, ok := config.Rules[d.Rule]
+
+		switch {
+		case ok && severity == 0:
+			continue
+		case ok:
+			d.Severity = severity
+		}
+
+		d.File = file
+		result = append(result, d)
+	}
+
+	slices.SortStableFunc(result, func(a, b *parser.Diagnostic) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+
+		return a.Column - b.Column
+	})
+
+	return result
+}
+
+// GetExitCode returns exit code for given findings
+func GetExitCode(diags parser.Diagnostics) int {
+	code := EXIT_CLEAN
+
+	for _, d := range diags {
+		switch d.Severity {
+		case parser.SEVERITY_ERROR:
+			return EXIT_ERRORS
+		case parser.SEVERITY_WARNING:
+			code = EXIT_WARNINGS
+		}
+	}
+
+	return code
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkArgumentsOrder checks that arguments documented in ascending order and
+// variadic argument is the last one
+func checkArgumentsOrder(doc *script.Document) parser.Diagnostics {
+	var result parser.Diagnostics
+
+	for _, m := range doc.Methods {
+		prev := 0
+
+		for i, a := range m.Arguments {
+			if a.IsVariadic() {
+				if i != len(m.Arguments)-1 {
+					result = append(result, newMethodDiagnostic(
+						m, parser.SEVERITY_WARNING, RULE_ARGUMENT_ORDER,
+						fmt.Sprintf("Variadic argument of method %s must be documented last", m.Name),
+					))
+				}
+
+				continue
+			}
+
+			if a.From <= prev {
+				result = append(result, newMethodDiagnostic(
+					m, parser.SEVERITY_WARNING, RULE_ARGUMENT_ORDER,
+					fmt.Sprintf("Argument %s of method %s documented out of order", a.Index, m.Name),
+				))
+			}
+
+			prev = max(a.From, a.To)
+		}
+	}
+
+	return result
+}
+
+// checkExamples checks that examples call only existing functions and
+// allowed commands
+func checkExamples(doc *script.Document, lines, defined []string, config *Config) parser.Diagnostics {
+	var result parser.Diagnostics
+
+	for _, m := range doc.Methods {
+		var reported []string
+
+		for _, line := range m.Example {
+			for _, cmd := range parser.ExtractCommands(line) {
+				if slices.Contains(defined, cmd) || slices.Contains(reported, cmd) || isCommand(cmd, config) {
+					continue
+				}
+
+				d := newMethodDiagnostic(
+					m, parser.SEVERITY_WARNING, RULE_UNKNOWN_CALL,
+					fmt.Sprintf("Example for method %s calls unknown function %s", m.Name, cmd),
+				)
+
+				d.Line, d.Column = findExampleCall(lines, m, line, cmd)
+				result = append(result, d)
+				reported = append(reported, cmd)
+			}
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getDefinedMethods returns names of all methods defined in script and
+// scripts sourced by it
+func getDefinedMethods(file string, opts *parser.Options) []string {
+	var result []string
+
+	set, _ := parser.ParseSet(file, opts)
+
+	for _, d := range set.Documents {
+		result = append(result, parser.FindMethods(d.File)...)
+	}
+
+	return result
+}
+
+// isCommand returns true if given name is shell builtin, common command or
+// allowed command
+func isCommand(name string, config *Config) bool {
+	switch {
+	case slices.Contains(shellBuiltins, name),
+		slices.Contains(commonCommands, name),
+		slices.Contains(config.Commands, name):
+		return true
+	}
+
+	if !config.LookupPath {
+		return false
+	}
+
+	_, err := exec.LookPath(name)
+
+	return err == nil
+}
+
+// findExampleCall returns position of command call in example line from
+// method comment (or position of method if line can't be found)
+func findExampleCall(lines []string, m *script.Method, example, cmd string) (int, int) {
+	example = strings.TrimSpace(example)
+
+	for i := min(m.Line-2, len(lines)-1); i >= 0; i-- {
+		line := strings.TrimLeft(lines[i], " \t")
+
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+
+		if strings.TrimSpace(line[1:]) != example {
+			continue
+		}
+
+		offset := len(lines[i]) - len(line) + 1
+		offset += strings.Index(lines[i][offset:], example)
+
+		return i + 1, offset + strings.Index(example, cmd) + 1
+	}
+
+	return m.Line, 1
+}
+
+// readLines returns lines of given file
+func readLines(file string) []string {
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		return nil
+	}
+
+	return strings.Split(string(data), "\n")
+}
+
+// newMethodDiagnostic creates new diagnostic for given method
+func newMethodDiagnostic(m *script.Method, severity parser.Severity, rule, message string) *parser.Diagnostic {
+	return &parser.Diagnostic{
+		Line:     m.Line,
+		Column:   1,
+		Severity: severity,
+		Rule:     rule,
+		Message:  message,
+		Entity:   m.Name,
+	}
+}
@@ -0,0 +1,269 @@
+package lint
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"testing"
+
+	"github.com/essentialkaos/shdoc/parser"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const _SCRIPT = `#!/bin/bash
+
+# Max number of retries (Number)
+MAX_RETRIES=3
+
+# (String)
+user_name=""
+
+# Print greeting
+#
+# 2: Name (String)
+# 1: Greeting (String)
+#
+# Example:
+#   greet "Hello" "John" | grep John
+#   notExistFunc "Hello"
+#
+greet() {
+  helper "$1" "$2"
+}
+
+# Print all arguments
+#
+# *: Arguments
+# 1: First argument
+#
+# Example:
+#   printAll 1 2 3
+#   user_name
+#
+printAll() {
+  echo "$@"
+}
+
+helper() {
+  echo "$1 $2"
+}
+`
+
+const _SCRIPT_CLEAN = `#!/bin/bash
+
+# Print greeting
+#
+# 1: Greeting (String)
+#
+# Example:
+#   greet "Hello"
+#
+greet() {
+  echo "$1"
+}
+`
+
+const _SCRIPT_SOURCED = `#!/bin/bash
+
+source "$(dirname "$0")/lib.sh"
+
+# Print greeting
+#
+# 1: Greeting (String)
+#
+# Example:
+#   greet "Hello" | upper
+#
+greet() {
+  echo "$1"
+}
+`
+
+const _SCRIPT_LIB = `#!/bin/bash
+
+upper() {
+  tr '[:lower:]' '[:upper:]'
+}
+`
+
+const _CONFIG = `
+[rules]
+  missing-docs: off
+  unknown-call: error
+  argument-order: notice
+
+[commands]
+  allow: grep, jq
+`
+
+const _CONFIG_LOOKUP = `
+[commands]
+  lookup-path: true
+`
+
+const _CONFIG_UNKNOWN_RULE = `
+[rules]
+  unknown-rule: on
+`
+
+const _CONFIG_UNKNOWN_VALUE = `
+[rules]
+  missing-docs: maybe
+`
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+type LintSuite struct {
+	TmpDir string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&LintSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *LintSuite) SetUpSuite(c *C) {
+	s.TmpDir = c.MkDir()
+
+	files := map[string]string{
+		"script.sh": _SCRIPT,
+		"clean.sh":  _SCRIPT_CLEAN,
+		"main.sh":   _SCRIPT_SOURCED,
+		"lib.sh":    _SCRIPT_LIB,
+		"lint.knf":  _CONFIG,
+		"lint0.knf": _CONFIG_LOOKUP,
+		"lint1.knf": _CONFIG_UNKNOWN_RULE,
+		"lint2.knf": _CONFIG_UNKNOWN_VALUE,
+	}
+
+	for name, data := range files {
+		err := os.WriteFile(s.TmpDir+"/"+name, []byte(data), 0644)
+
+		if err != nil {
+			c.Fatal(err.Error())
+		}
+	}
+}
+
+func (s *LintSuite) TestCheck(c *C) {
+	config := DefaultConfig()
+	config.Commands = []string{"grep"}
+
+	diags := Check(s.TmpDir+"/script.sh", config)
+
+	c.Assert(diags, HasLen, 6)
+
+	c.Assert(diags[0].Rule, Equals, parser.RULE_MISSING_DESC)
+	c.Assert(diags[0].Entity, Equals, "user_name")
+	c.Assert(diags[0].Line, Equals, 6)
+	c.Assert(diags[1].Rule, Equals, RULE_UNKNOWN_CALL)
+	c.Assert(diags[1].Message, Equals, "Example for method greet calls unknown function notExistFunc")
+	c.Assert(diags[1].Line, Equals, 16)
+	c.Assert(diags[1].Column, Equals, 5)
+	c.Assert(diags[2].Rule, Equals, RULE_ARGUMENT_ORDER)
+	c.Assert(diags[2].Entity, Equals, "greet")
+	c.Assert(diags[2].Line, Equals, 18)
+	c.Assert(diags[3].Rule, Equals, RULE_UNKNOWN_CALL)
+	c.Assert(diags[3].Message, Equals, "Example for method printAll calls unknown function user_name")
+	c.Assert(diags[3].Line, Equals, 29)
+	c.Assert(diags[3].Column, Equals, 5)
+	c.Assert(diags[4].Rule, Equals, RULE_ARGUMENT_ORDER)
+	c.Assert(diags[4].Entity, Equals, "printAll")
+	c.Assert(diags[4].Line, Equals, 31)
+	c.Assert(diags[4].Message, Equals, "Variadic argument of method printAll must be documented last")
+	c.Assert(diags[5].Rule, Equals, parser.RULE_MISSING_DOCS)
+	c.Assert(diags[5].Severity, Equals, parser.SEVERITY_WARNING)
+	c.Assert(diags[5].Line, Equals, 35)
+
+	for _, d := range diags {
+		c.Assert(d.File, Equals, s.TmpDir+"/script.sh")
+	}
+
+	c.Assert(GetExitCode(diags), Equals, EXIT_WARNINGS)
+
+	diags = Check(s.TmpDir+"/clean.sh", DefaultConfig())
+
+	c.Assert(diags, HasLen, 0)
+	c.Assert(GetExitCode(diags), Equals, EXIT_CLEAN)
+
+	diags = Check(s.TmpDir+"/main.sh", nil)
+
+	c.Assert(diags, HasLen, 0)
+
+	diags = Check(s.TmpDir+"/unknown.sh", nil)
+
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0].Rule, Equals, parser.RULE_OPEN_ERROR)
+	c.Assert(GetExitCode(diags), Equals, EXIT_ERRORS)
+}
+
+func (s *LintSuite) TestCommands(c *C) {
+	config := DefaultConfig()
+
+	c.Assert(isCommand("echo", config), Equals, true)
+	c.Assert(isCommand("grep", config), Equals, true)
+	c.Assert(isCommand("jq", config), Equals, false)
+	c.Assert(isCommand("go", config), Equals, false)
+
+	config.Commands = []string{"jq"}
+
+	c.Assert(isCommand("jq", config), Equals, true)
+
+	config.LookupPath = true
+
+	c.Assert(isCommand("go", config), Equals, true)
+	c.Assert(isCommand("notExistCommand", config), Equals, false)
+
+	diags := Check(s.TmpDir+"/script.sh", nil)
+
+	c.Assert(diags, HasLen, 6)
+	c.Assert(diags[1].Message, Equals, "Example for method greet calls unknown function notExistFunc")
+	c.Assert(diags[3].Message, Equals, "Example for method printAll calls unknown function user_name")
+
+	c.Assert(getDefinedMethods(s.TmpDir+"/main.sh", nil), DeepEquals, []string{"greet", "upper"})
+	c.Assert(getDefinedMethods(s.TmpDir+"/script.sh", nil), DeepEquals, []string{"greet", "printAll", "helper"})
+}
+
+func (s *LintSuite) TestConfig(c *C) {
+	cfg, err := ReadConfig(s.TmpDir + "/lint.knf")
+
+	c.Assert(err, IsNil)
+	c.Assert(cfg, NotNil)
+
+	c.Assert(cfg.Commands, DeepEquals, []string{"grep", "jq"})
+	c.Assert(cfg.LookupPath, Equals, false)
+
+	diags := Check(s.TmpDir+"/script.sh", cfg)
+
+	c.Assert(diags, HasLen, 5)
+	c.Assert(diags[1].Severity, Equals, parser.SEVERITY_ERROR)
+	c.Assert(diags[2].Severity, Equals, parser.SEVERITY_NOTICE)
+	c.Assert(GetExitCode(diags), Equals, EXIT_ERRORS)
+
+	cfg, err = ReadConfig(s.TmpDir + "/lint0.knf")
+
+	c.Assert(err, IsNil)
+	c.Assert(cfg.LookupPath, Equals, true)
+
+	_, err = ReadConfig(s.TmpDir + "/lint1.knf")
+	c.Assert(err, ErrorMatches, `Unknown rule "unknown-rule"`)
+
+	_, err = ReadConfig(s.TmpDir + "/lint2.knf")
+	c.Assert(err, ErrorMatches, `Unsupported value "maybe" for rule "missing-docs"`)
+
+	_, err = ReadConfig(s.TmpDir + "/lint3.knf")
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,413 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Annotations of annotated dialect
+const (
+	ANN_FILE        = "file"
+	ANN_NAME        = "name"
+	ANN_BRIEF       = "brief"
+	ANN_DESCRIPTION = "description"
+	ANN_ARG         = "arg"
+	ANN_NOARGS      = "noargs"
+	ANN_OPTION      = "option"
+	ANN_SET         = "set"
+	ANN_EXITCODE    = "exitcode"
+	ANN_STDIN       = "stdin"
+	ANN_STDOUT      = "stdout"
+	ANN_STDERR      = "stderr"
+	ANN_EXAMPLE     = "example"
+	ANN_INTERNAL    = "internal"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// annotatedDialect is dialect with annotations (@description, @arg $1 string,
+// @exitcode 0, @stdout…)
+type annotatedDialect struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	annotationRegExp = regexp.MustCompile(`^@(description|arg|noargs|set|exitcode|stdout|stderr|stdin)([ \t]|$)`)
+	annArgRegExp     = regexp.MustCompile(`^\$([0-9]{1,}|@|\*)(?:[ \t]+(.*))?$`)
+	annSetRegExp     = regexp.MustCompile(`^\$?([a-zA-Z_][a-zA-Z0-9_]*)(?:[ \t]+(.*))?$`)
+	annCodeRegExp    = regexp.MustCompile(`^([0-9]{1,})(?:[ \t]+(.*))?$`)
+)
+
+// annotations is a slice with all supported annotations
+var annotations = []string{
+	ANN_FILE, ANN_NAME, ANN_BRIEF, ANN_DESCRIPTION, ANN_ARG, ANN_NOARGS,
+	ANN_OPTION, ANN_SET, ANN_EXITCODE, ANN_STDIN, ANN_STDOUT, ANN_STDERR,
+	ANN_EXAMPLE, ANN_INTERNAL,
+}
+
+// annTypeAliases contains aliases for type names used in annotations
+var annTypeAliases = map[string]script.VariableType{
+	"str":     script.VAR_TYPE_STRING,
+	"int":     script.VAR_TYPE_NUMBER,
+	"integer": script.VAR_TYPE_NUMBER,
+	"num":     script.VAR_TYPE_NUMBER,
+	"float":   script.VAR_TYPE_NUMBER,
+	"bool":    script.VAR_TYPE_BOOLEAN,
+	"list":    script.VAR_TYPE_ARRAY,
+	"hash":    script.VAR_TYPE_MAP,
+	"dict":    script.VAR_TYPE_MAP,
+	"any":     script.VAR_TYPE_UNKNOWN,
+	"mixed":   script.VAR_TYPE_UNKNOWN,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Name returns name of dialect
+func (d *annotatedDialect) Name() string {
+	return DIALECT_ANNOTATED
+}
+
+// Detect returns true if given comment lines are written in dialect
+func (d *annotatedDialect) Detect(comments []string) bool {
+	for _, line := range comments {
+		if annotationRegExp.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseAbout parses comment with info about script
+func (d *annotatedDialect) ParseAbout(data []string) []string {
+	var result []string
+
+	for _, line := range data {
+		if !isTagLine(line) {
+			result = append(result, line)
+			continue
+		}
+
+		tag, value := parseAnnotation(line)
+
+		switch tag {
+		case ANN_BRIEF:
+			result = append(result, value, "")
+		case ANN_DESCRIPTION:
+			result = append(result, value)
+		case ANN_FILE, ANN_NAME:
+			continue
+		default:
+			result = append(result, line)
+		}
+	}
+
+	return getCleanData(trimEmptyLines(result))
+}
+
+// ParseMethod parses method comment and returns method info
+func (d *annotatedDialect) ParseMethod(name string, data []string) *script.Method {
+	if isIgnored(data) || hasAnnotation(data, ANN_INTERNAL) {
+		return nil
+	}
+
+	method := &script.Method{Name: name}
+
+	var desc, tags []string
+	var skip int
+
+	for index, line := range data {
+		// Skip lines of multi-line block
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		if !isTagLine(line) {
+			desc = append(desc, line)
+			continue
+		}
+
+		tag, value := parseAnnotation(line)
+
+		switch tag {
+		case ANN_DESCRIPTION:
+			if value != "" {
+				desc = append(desc, value)
+			}
+
+		case ANN_ARG:
+			if !annArgRegExp.MatchString(value) {
+				continue
+			}
+
+			var descData []string
+
+			argument := parseAnnotatedArgument(value)
+			descData, skip = getContinuationLines(data[index+1:])
+			appendArgumentDesc(argument, descData)
+
+			method.Arguments = append(method.Arguments, argument)
+
+		case ANN_SET:
+			if !annSetRegExp.MatchString(value) {
+				continue
+			}
+
+			vd := annSetRegExp.FindStringSubmatch(value)
+			_, setDesc := cutAnnotatedType(vd[2])
+
+			method.Modifies = append(method.Modifies, &script.VariableRef{Name: vd[1], Desc: setDesc})
+
+		case ANN_EXITCODE:
+			if !annCodeRegExp.MatchString(value) {
+				continue
+			}
+
+			var codeData []string
+
+			cd := annCodeRegExp.FindStringSubmatch(value)
+			code, _ := strconv.Atoi(cd[1])
+			codeData, skip = getContinuationLines(data[index+1:])
+
+			if method.ResultCodes == nil {
+				method.ResultCodes = map[int]string{}
+			}
+
+			method.ResultCodes[code] = strings.Join(append([]string{cd[2]}, codeData...), "\n")
+
+		case ANN_STDOUT:
+			var echoData []string
+
+			echoData, skip = getBlockLines(value, data[index+1:])
+			method.ResultEcho = parseVariableComment("", "", echoData)
+
+		case ANN_STDERR:
+			method.Stderr, skip = getBlockLines(value, data[index+1:])
+
+		case ANN_EXAMPLE:
+			method.Example, skip = getExampleLines(value, data[index+1:])
+
+		case TAG_DEPRECATED, TAG_SINCE, TAG_SEE, TAG_AUTHOR:
+			tags = append(tags, line)
+		}
+	}
+
+	method.Desc = getCleanData(trimEmptyLines(desc))
+	_, method.Tags = parseTags(tags)
+
+	return method
+}
+
+// ParseVariable parses variable comment and returns variable info
+func (d *annotatedDialect) ParseVariable(name, value string, data []string) *script.Variable {
+	if isIgnored(data) || hasAnnotation(data, ANN_INTERNAL) {
+		return nil
+	}
+
+	var result []string
+
+	for _, line := range data {
+		tag, desc := parseAnnotation(line)
+
+		if tag == ANN_DESCRIPTION {
+			line = desc
+		}
+
+		result = append(result, line)
+	}
+
+	return parseVariableComment(name, value, result)
+}
+
+// ValidateMethod checks method comment and returns slice with diagnostics
+func (d *annotatedDialect) ValidateMethod(data []string, pos []LinePos) Diagnostics {
+	var result Diagnostics
+	var indexes []string
+
+	for index, line := range data {
+		tag, value := parseAnnotation(line)
+		offset := strings.Index(line, value)
+
+		if tag == ANN_EXITCODE && annCodeRegExp.MatchString(value) {
+			if d := validateCode(annCodeRegExp.FindStringSubmatch(value)[1], pos[index], offset); d != nil {
+				result = append(result, d)
+			}
+		}
+
+		if tag != ANN_ARG {
+			continue
+		}
+
+		if !annArgRegExp.MatchString(value) {
+			result = append(result, newDiagnostic(
+				pos[index], offset, SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT,
+				"Annotation @arg doesn't contain argument index",
+			))
+
+			continue
+		}
+
+		argument := parseAnnotatedArgument(value)
+
+		switch {
+		case argument.From == 0:
+			result = append(result, newDiagnostic(
+				pos[index], offset, SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT,
+				"Argument index must be greater than 0",
+			))
+		case argument.Desc == "":
+			result = append(result, newDiagnostic(
+				pos[index], offset, SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT,
+				fmt.Sprintf("Argument %s doesn't have description", argument.Index),
+			))
+		}
+
+		if slices.Contains(indexes, argument.Index) {
+			result = append(result, newDiagnostic(
+				pos[index], offset, SEVERITY_ERROR, RULE_DUPLICATE_ARGUMENT,
+				fmt.Sprintf("Argument %s is documented more than once", argument.Index),
+			))
+		}
+
+		indexes = append(indexes, argument.Index)
+	}
+
+	return append(result, checkTags(data, pos, slices.Concat(knownTags, annotations))...)
+}
+
+// ValidateVariable checks variable comment and returns slice with diagnostics
+func (d *annotatedDialect) ValidateVariable(data []string, pos []LinePos) Diagnostics {
+	return append(
+		validateVariableComment(data, pos),
+		checkTags(data, pos, slices.Concat(knownTags, annotations))...,
+	)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseAnnotation returns name (in lower case) and value of annotation or
+// empty strings if line doesn't contain annotation
+func parseAnnotation(line string) (string, string) {
+	line = strings.TrimRight(line, " ")
+
+	if !tagRegExp.MatchString(line) {
+		return "", ""
+	}
+
+	td := tagRegExp.FindStringSubmatch(line)
+
+	return strings.ToLower(td[1]), strings.TrimSpace(td[2])
+}
+
+// hasAnnotation returns true if comment contains annotation with given name
+func hasAnnotation(data []string, name string) bool {
+	for _, line := range data {
+		if tag, _ := parseAnnotation(line); tag == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseAnnotatedArgument parses value of @arg annotation ($1 string Desc)
+func parseAnnotatedArgument(value string) *script.Argument {
+	ad := annArgRegExp.FindStringSubmatch(value)
+	index := ad[1]
+
+	if index == "@" {
+		index = "*"
+	}
+
+	t, desc := cutAnnotatedType(ad[2])
+	argument := parseArgumentComment(index + ": " + desc)
+
+	if argument.Type == script.VAR_TYPE_UNKNOWN {
+		argument.Type = t
+	}
+
+	return argument
+}
+
+// cutAnnotatedType returns type from the first word of value and the rest of
+// value, value is returned as is if the first word isn't a type name
+func cutAnnotatedType(value string) (script.VariableType, string) {
+	word, rest, _ := strings.Cut(value, " ")
+
+	if t, ok := annTypeAliases[strings.ToLower(word)]; ok {
+		return t, strings.TrimSpace(rest)
+	}
+
+	for _, info := range script.Types() {
+		if strings.EqualFold(info.Name, word) {
+			return info.Type, strings.TrimSpace(rest)
+		}
+	}
+
+	return script.VAR_TYPE_UNKNOWN, value
+}
+
+// getExampleLines returns lines of example (value of the record and following
+// indented lines without common indentation) and number of used lines
+func getExampleLines(value string, data []string) ([]string, int) {
+	var result []string
+
+	if value != "" {
+		result = append(result, value)
+	}
+
+	lines := len(data)
+
+	for index, line := range data {
+		if line != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			lines = index
+			break
+		}
+	}
+
+	indent := -1
+
+	for _, line := range data[:lines] {
+		size := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if strings.TrimSpace(line) != "" && (indent == -1 || size < indent) {
+			indent = size
+		}
+	}
+
+	for _, line := range data[:lines] {
+		if len(line) < indent {
+			line = ""
+		} else if indent > 0 {
+			line = line[indent:]
+		}
+
+		result = append(result, line)
+	}
+
+	return getCleanData(result), lines
+}
+
+// trimEmptyLines removes empty lines from the beginning of data
+func trimEmptyLines(data []string) []string {
+	for len(data) != 0 && strings.TrimSpace(data[0]) == "" {
+		data = data[1:]
+	}
+
+	return data
+}
@@ -0,0 +1,213 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// autoloadExtensions contains extensions of files with autoloaded functions
+var autoloadExtensions = []string{"", ".zsh", ".ksh", ".sh"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ParseAutoload parses directory with autoloaded functions (every file is a
+// body of function with the same name) and returns document with one method
+// per file and slice with diagnostics
+func ParseAutoload(dir string, opts *Options) (*script.Document, Diagnostics) {
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return nil, Diagnostics{{
+			File:     dir,
+			Severity: SEVERITY_ERROR,
+			Rule:     RULE_OPEN_ERROR,
+			Message:  fmt.Sprintf("Can't open autoload directory: %v", err),
+		}}
+	}
+
+	var diags Diagnostics
+	var bodies []*methodBody
+	var links []*link
+
+	doc := &script.Document{Title: filepath.Base(dir), File: dir, Shell: SHELL_ZSH}
+
+	for _, entry := range entries {
+		if !isAutoloadEntry(entry) {
+			continue
+		}
+
+		file := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(file)
+
+		if err != nil {
+			diags = append(diags, &Diagnostic{
+				File:     file,
+				Severity: SEVERITY_ERROR,
+				Rule:     RULE_OPEN_ERROR,
+				Message:  fmt.Sprintf("Can't open script file: %v", err),
+			})
+
+			continue
+		}
+
+		fileDoc, fileBodies, fileLinks, fileDiags := readAutoload(file, data, opts)
+
+		doc.Methods = append(doc.Methods, fileDoc.Methods...)
+		bodies = append(bodies, fileBodies...)
+		links = append(links, fileLinks...)
+		diags = append(diags, fileDiags...)
+	}
+
+	linkBodies(doc, bodies)
+
+	return doc, append(diags, checkLinks(doc, links)...)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readAutoload reads file with autoloaded function and returns document with
+// one method named after the file, methods bodies, explicit references and
+// diagnostics
+func readAutoload(file string, data []byte, opts *Options) (*script.Document, []*methodBody, []*link, Diagnostics) {
+	var diags Diagnostics
+	var links []*link
+
+	sh := shells[SHELL_ZSH]
+	name := getAutoloadName(file)
+	doc := &script.Document{Title: filepath.Base(file), File: file, Shell: sh.Name}
+
+	// The whole file is a body of function
+	body := &methodBody{
+		Shell:    sh,
+		Lines:    strings.Split(strings.TrimRight(string(data), "\n"), "\n"),
+		Start:    1,
+		IsClosed: true,
+	}
+
+	buffer, bufferPos := readAutoloadComment(body.Lines, sh)
+	d := opts.getDialect()
+
+	if d == nil {
+		d = detectBlocksDialect([][]string{buffer})
+	}
+
+	switch {
+	case len(buffer) == 0:
+		diags = append(diags, &Diagnostic{
+			Line:     1,
+			Column:   1,
+			Severity: SEVERITY_NOTICE,
+			Rule:     RULE_MISSING_DOCS,
+			Message:  fmt.Sprintf("Method %s doesn't have documentation", name),
+			Entity:   name,
+		})
+
+	default:
+		m := d.ParseMethod(name, buffer)
+
+		if m == nil {
+			break
+		}
+
+		m.Line, m.File = 1, file
+
+		// Methods MUST have description
+		if !hasDesc(m.Desc) {
+			diags = append(diags, newMissingDescDiagnostic(name, bufferPos[0]))
+			break
+		}
+
+		doc.Methods = append(doc.Methods, m)
+		diags = append(diags, validateMethod(d, buffer, bufferPos)...)
+		links = append(links, findLinks(file, buffer, bufferPos)...)
+		body.Method, body.Comment, body.CommentPos = m, buffer, bufferPos
+		diags = append(diags, body.Check()...)
+	}
+
+	for _, d := range diags {
+		d.File = file
+	}
+
+	return doc, []*methodBody{body}, links, diags
+}
+
+// readAutoloadComment returns the first comment block of autoloaded function
+// and positions of comment lines
+func readAutoloadComment(lines []string, sh *shell) ([]string, []LinePos) {
+	var buffer []string
+	var bufferPos []LinePos
+
+	for index, line := range lines {
+		indent := len(line)
+		line = strings.TrimLeft(line, " ")
+		indent -= len(line)
+
+		switch {
+		case index == 0 && (strings.HasPrefix(line, "#!") || autoloadRegExp.MatchString(line)),
+			shellcheckRegexp.MatchString(line):
+			continue
+
+		case line == "":
+			if buffer != nil {
+				return buffer, bufferPos
+			}
+
+		case strings.Trim(line, "#") == "":
+			if buffer != nil {
+				buffer = append(buffer, "")
+				bufferPos = append(bufferPos, LinePos{index + 1, indent + 2})
+			}
+
+		case line[0] == '#':
+			buffer = append(buffer, line[2:])
+			bufferPos = append(bufferPos, LinePos{index + 1, indent + 3})
+
+		// Header commands (emulate -L zsh) can precede comment
+		case buffer == nil && sh.HeaderRegExp.MatchString(line):
+			continue
+
+		default:
+			return buffer, bufferPos
+		}
+	}
+
+	return buffer, bufferPos
+}
+
+// getAutoloadName returns name of function defined in file
+func getAutoloadName(file string) string {
+	name := filepath.Base(file)
+	ext := filepath.Ext(name)
+
+	if ext != "" && slices.Contains(autoloadExtensions, ext) {
+		return strings.TrimSuffix(name, ext)
+	}
+
+	return name
+}
+
+// isAutoloadEntry returns true if directory entry is a file with autoloaded
+// function
+func isAutoloadEntry(entry os.DirEntry) bool {
+	switch {
+	case !entry.Type().IsRegular(),
+		strings.HasPrefix(entry.Name(), "."):
+		return false
+	}
+
+	return slices.Contains(autoloadExtensions, filepath.Ext(entry.Name()))
+}
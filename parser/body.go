@@ -0,0 +1,776 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	shiftRegExp    = regexp.MustCompile(`(?:^|[ \t;&|{(])shift(?:[ \t]+([^ \t;&|)}]+))?[ \t]*(?:$|[;&|)}#])`)
+	setArgRegExp   = regexp.MustCompile(`(?:^|[ \t;&|{(])set[ \t]+--`)
+	loopRegExp     = regexp.MustCompile(`(?:^|[ \t;&|{(])(do|done)(?:$|[ \t;&|)}])`)
+	cmdSepRegExp   = regexp.MustCompile(`\$\(|\|\||&&|[;|&(){}` + "`" + `]`)
+	assignRegExp   = regexp.MustCompile(`^([a-zA-Z_][a-zA-Z0-9_]*)(?:\[[^\]]*\])?\+?=`)
+	incRegExp      = regexp.MustCompile(`\(\([ \t]*([a-zA-Z_][a-zA-Z0-9_]*)[ \t]*(?:\+\+|--)`)
+	localArgRegExp = regexp.MustCompile(`(?:^|[ \t;&|{(])local(?:[ \t]+-[a-zA-Z]+)*[ \t]+([a-zA-Z_][a-zA-Z0-9_]*)="?$`)
+	varNameRegExp  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*`)
+)
+
+// shellKeywords contains shell keywords which can precede command
+var shellKeywords = []string{
+	"!", "do", "elif", "else", "if", "then", "time", "until", "while",
+}
+
+// shellReserved contains shell reserved words which can't be followed by command
+var shellReserved = []string{
+	"[[", "]]", "case", "done", "esac", "fi", "for", "function", "in", "select",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// methodBody contains lines of method body
+type methodBody struct {
+	Method     *script.Method // Documented method
+	Shell      *shell         // Rules of script shell
+	Comment    []string       // Method comment
+	CommentPos []LinePos      // Positions of comment lines
+	Indent     int            // Indent of method definition
+	Lines      []string       // Body lines
+	Start      int            // Number of the first line of body
+	IsClosed   bool           // Body is closed
+}
+
+// paramsUsage contains info about positional parameters usage
+type paramsUsage struct {
+	Params   map[int]LinePos // Positions of the first usage of parameters
+	Names    map[int]string  // Names of local variables with parameters values
+	All      LinePos         // Position of the first usage of $@ or $*
+	IsUnsure bool            // Parameters are modified in a way we can't track
+}
+
+// paramRef contains info about reference to positional parameter
+type paramRef struct {
+	Index int // Parameter index (0 for $@ and $*)
+	Col   int // Column of reference
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newMethodBody creates new method body starting with definition line
+func newMethodBody(line string, lineNum, indent int, sh *shell) *methodBody {
+	b := &methodBody{Shell: sh, Indent: indent, Start: lineNum}
+	b.Lines = append(b.Lines, strings.Repeat(" ", indent)+line)
+
+	// One-line definition (name() { … ; })
+	code := strings.TrimRight(line, " \t")
+	b.IsClosed = strings.HasSuffix(code, "}") ||
+		(strings.HasSuffix(code, ")") && strings.Count(code, "(") > 1)
+
+	return b
+}
+
+// Add adds line to the body
+func (b *methodBody) Add(line string, isCode bool) {
+	b.Lines = append(b.Lines, line)
+
+	if !isCode {
+		return
+	}
+
+	code := strings.TrimLeft(line, " \t")
+
+	if len(line)-len(code) <= b.Indent && (strings.HasPrefix(code, "}") || strings.HasPrefix(code, ")")) {
+		b.IsClosed = true
+	}
+}
+
+// Check compares usage of positional parameters with method documentation
+// and returns slice with diagnostics
+func (b *methodBody) Check() Diagnostics {
+	if b == nil || b.Method == nil {
+		return nil
+	}
+
+	var result Diagnostics
+
+	usage := b.Usage()
+
+	var indexes []int
+
+	for index := range usage.Params {
+		indexes = append(indexes, index)
+	}
+
+	slices.Sort(indexes)
+
+	for _, index := range indexes {
+		if isDocumentedPosition(b.Method, index) {
+			continue
+		}
+
+		result = append(result, newDiagnostic(
+			usage.Params[index], 0, SEVERITY_WARNING, RULE_UNDOCUMENTED_ARGUMENT,
+			fmt.Sprintf("Argument %d of method %s is used but not documented", index, b.Method.Name),
+		))
+	}
+
+	if usage.All.Line != 0 && !slices.ContainsFunc(b.Method.Arguments, (*script.Argument).IsVariadic) {
+		result = append(result, newDiagnostic(
+			usage.All, 0, SEVERITY_WARNING, RULE_MISSING_WILDCARD,
+			fmt.Sprintf("Method %s uses all arguments but wildcard argument is not documented", b.Method.Name),
+		))
+	}
+
+	if usage.All.Line != 0 || usage.IsUnsure {
+		return result
+	}
+
+	var reported []string
+
+	for _, arg := range b.Method.Arguments {
+		switch {
+		case arg.From == 0, !arg.IsVariadic() && arg.To < arg.From, // Malformed index
+			isUsedArgument(arg, usage), slices.Contains(reported, arg.Index):
+			continue
+		}
+
+		reported = append(reported, arg.Index)
+		pos, offset := b.findArgument(arg)
+
+		result = append(result, newDiagnostic(
+			pos, offset, SEVERITY_WARNING, RULE_UNUSED_ARGUMENT,
+			fmt.Sprintf("Argument %s of method %s is documented but never used", arg.Index, b.Method.Name),
+		))
+	}
+
+	return result
+}
+
+// findArgument returns position of argument record in method comment, the
+// first comment line is used if record can't be found
+func (b *methodBody) findArgument(arg *script.Argument) (LinePos, int) {
+	index, params := regexp.QuoteMeta(arg.Index), regexp.QuoteMeta(arg.Index)
+
+	if arg.Index == "*" {
+		params = `[@*]`
+	}
+
+	// 1: Desc, 1 name: Desc, @arg $1 Desc, $1 - Desc
+	re := regexp.MustCompile(
+		`^[ \t]*(?:(?:@arg[ \t]+)?\$(` + params + `)(?:[ \t:-]|$)|(` + index + `)(?:[ \t]+[a-zA-Z_][a-zA-Z0-9_]*)?:)`,
+	)
+
+	for i, line := range b.Comment {
+		if strings.HasPrefix(line, "Example:") {
+			break
+		}
+
+		if loc := re.FindStringSubmatchIndex(line); loc != nil {
+			return b.CommentPos[i], max(loc[2], loc[4])
+		}
+	}
+
+	return b.CommentPos[0], 0
+}
+
+// Usage returns info about usage of positional parameters in method body
+func (b *methodBody) Usage() *paramsUsage {
+	var refs []paramRef
+	var shifted, loops int
+
+	usage := &paramsUsage{Params: map[int]LinePos{}, Names: map[int]string{}}
+	lx := &lexer{}
+
+	lx.onDollar = func(line string, i int) {
+		index, ok := parseParamRef(line, i)
+
+		if !ok && b.Shell.HasArgv {
+			index, ok = parseArgvRef(line, i)
+		}
+
+		if ok {
+			refs = append(refs, paramRef{index, i + 1})
+		}
+	}
+
+LINES:
+	for index, line := range b.Lines {
+		refs = refs[:0]
+		inCode := !lx.InHeredoc() && !lx.IsOpen()
+		code := stripComment(line)
+
+		// Parameters are expanded in here-documents with unquoted delimiter
+		if lx.IsExpanding() {
+			for i := range len(line) {
+				if line[i] == '$' && byteAt(line, i-1) != '\\' {
+					lx.onDollar(line, i)
+				}
+			}
+		}
+
+		lx.Feed(line)
+
+		// Shifts and loops are tracked only in lines of code
+		var shifts [][]int
+
+		if inCode {
+			if setArgRegExp.MatchString(code) {
+				usage.IsUnsure = true
+			}
+
+			for _, m := range loopRegExp.FindAllStringSubmatch(code, -1) {
+				if m[1] == "do" {
+					loops++
+				} else if loops > 0 {
+					loops--
+				}
+			}
+
+			shifts = shiftRegExp.FindAllStringSubmatchIndex(code, -1)
+		}
+
+		pos := LinePos{Line: b.Start + index}
+
+		for _, ref := range refs {
+			for len(shifts) > 0 && shifts[0][1] <= ref.Col-1 {
+				usage.IsUnsure = usage.IsUnsure || !applyShift(code, shifts[0], loops, &shifted)
+				shifts = shifts[1:]
+			}
+
+			if usage.IsUnsure {
+				break LINES
+			}
+
+			pos.Column = ref.Col
+
+			if ref.Index == 0 {
+				if usage.All.Line == 0 {
+					usage.All = pos
+				}
+
+				continue
+			}
+
+			if _, ok := usage.Params[ref.Index+shifted]; !ok {
+				usage.Params[ref.Index+shifted] = pos
+			}
+
+			// local name="$1"
+			if _, ok := usage.Names[ref.Index+shifted]; !ok && inCode {
+				if m := localArgRegExp.FindStringSubmatch(line[:ref.Col-1]); m != nil {
+					usage.Names[ref.Index+shifted] = m[1]
+				}
+			}
+		}
+
+		for _, shift := range shifts {
+			usage.IsUnsure = usage.IsUnsure || !applyShift(code, shift, loops, &shifted)
+		}
+
+		if usage.IsUnsure {
+			break
+		}
+	}
+
+	return usage
+}
+
+// Commands returns names of commands called in method body
+func (b *methodBody) Commands() []string {
+	var result []string
+
+	lx := &lexer{}
+
+	for index, line := range b.Lines {
+		inCode := !lx.InHeredoc() && !lx.IsOpen()
+
+		lx.Feed(line)
+
+		if !inCode {
+			continue
+		}
+
+		line = stripComment(line)
+
+		// Remove method name from definition line
+		if index == 0 {
+			code := strings.TrimLeft(line, " \t")
+
+			if b.Shell.MethodRegExp.MatchString(code) {
+				line = code[len(b.Shell.MethodRegExp.FindString(code)):]
+			}
+		}
+
+		for _, cmd := range ExtractCommands(line) {
+			if !slices.Contains(result, cmd) {
+				result = append(result, cmd)
+			}
+		}
+	}
+
+	return result
+}
+
+// Variables returns names of global variables read and modified in method
+// body (local variables are ignored)
+func (b *methodBody) Variables() ([]string, []string) {
+	var reads, writes, locals []string
+
+	lx := &lexer{}
+
+	lx.onDollar = func(line string, i int) {
+		if name := parseVarRef(line, i); name != "" && !slices.Contains(reads, name) {
+			reads = append(reads, name)
+		}
+	}
+
+	for _, line := range b.Lines {
+		inCode := !lx.InHeredoc() && !lx.IsOpen()
+
+		if lx.IsExpanding() {
+			for i := range len(line) {
+				if line[i] == '$' && byteAt(line, i-1) != '\\' {
+					lx.onDollar(line, i)
+				}
+			}
+		}
+
+		lx.Feed(line)
+
+		if !inCode {
+			continue
+		}
+
+		code := stripComment(line)
+		assigned, declared := parseAssignments(code)
+
+		for _, m := range incRegExp.FindAllStringSubmatch(code, -1) {
+			assigned = append(assigned, m[1])
+		}
+
+		for _, name := range assigned {
+			if !slices.Contains(writes, name) {
+				writes = append(writes, name)
+			}
+		}
+
+		locals = append(locals, declared...)
+	}
+
+	isLocal := func(name string) bool { return slices.Contains(locals, name) }
+
+	return slices.DeleteFunc(reads, isLocal), slices.DeleteFunc(writes, isLocal)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ExtractCommands extracts names of commands from line of code
+func ExtractCommands(line string) []string {
+	var result []string
+
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return nil
+	}
+
+	for _, part := range cmdSepRegExp.Split(line, -1) {
+		for _, word := range strings.Fields(part) {
+			if slices.Contains(shellKeywords, word) {
+				continue // Keyword before command
+			}
+
+			if slices.Contains(shellReserved, word) || !isCommandName(word) {
+				break
+			}
+
+			result = append(result, word)
+			break
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseAssignments returns names of variables modified in line of code and
+// names of declared local variables. Only assignments in command position are
+// counted, declare and typeset without -g declare local variables.
+func parseAssignments(line string) ([]string, []string) {
+	var writes, locals []string
+
+	for _, words := range splitCommands(line) {
+		for len(words) != 0 && slices.Contains(shellKeywords, words[0]) {
+			words = words[1:]
+		}
+
+		var assigned []string
+
+		for len(words) != 0 && assignRegExp.MatchString(words[0]) {
+			assigned = append(assigned, assignRegExp.FindStringSubmatch(words[0])[1])
+			words = words[1:]
+		}
+
+		// Assignments before command only set its environment
+		if len(words) == 0 {
+			writes = append(writes, assigned...)
+			continue
+		}
+
+		flags, names, values := parseBuiltinArgs(words[1:])
+
+		switch words[0] {
+		case "local":
+			locals = append(locals, names...)
+		case "declare", "typeset":
+			if strings.Contains(flags, "g") {
+				writes = append(writes, names...)
+			} else {
+				locals = append(locals, names...)
+			}
+		case "export", "readonly":
+			writes = append(writes, values...)
+		case "unset":
+			if !strings.Contains(flags, "f") {
+				writes = append(writes, names...)
+			}
+		}
+	}
+
+	return writes, locals
+}
+
+// parseBuiltinArgs parses arguments of builtin (declare, local, unset…) and
+// returns flags, names of all variables and names of variables with values
+func parseBuiltinArgs(args []string) (string, []string, []string) {
+	var flags string
+	var names, values []string
+
+	for _, arg := range args {
+		if arg[0] == '-' {
+			flags += arg[1:]
+			continue
+		}
+
+		name := varNameRegExp.FindString(arg)
+
+		if name == "" {
+			continue
+		}
+
+		names = append(names, name)
+
+		if assignRegExp.MatchString(arg) {
+			values = append(values, name)
+		}
+	}
+
+	return flags, names, values
+}
+
+// splitCommands splits line of code into simple commands and returns words
+// of every command, quoted parts are kept in words as is
+func splitCommands(line string) [][]string {
+	var result [][]string
+	var words []string
+	var word strings.Builder
+	var quote byte
+
+	flushCommand := func() {
+		if len(words) != 0 {
+			result = append(result, words)
+			words = nil
+		}
+	}
+
+	flushWord := func() {
+		switch w := word.String(); w {
+		case "":
+			return
+		case "{", "}", "((", "))", "[[", "]]":
+			flushCommand()
+		default:
+			words = append(words, w)
+		}
+
+		word.Reset()
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		switch {
+		case c == '\\' && quote != '\'':
+			word.WriteByte(c)
+
+			if i+1 < len(line) {
+				i++
+				word.WriteByte(line[i])
+			}
+
+			continue
+
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+
+		case c == '\'' || c == '"':
+			quote = c
+
+		case c == ' ' || c == '\t':
+			flushWord()
+			continue
+
+		case strings.IndexByte(";&|()`", c) != -1:
+			flushWord()
+			flushCommand()
+			continue
+		}
+
+		word.WriteByte(c)
+	}
+
+	flushWord()
+	flushCommand()
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// linkBodies fills info extracted from methods bodies
+func linkBodies(doc *script.Document, bodies []*methodBody) {
+	linkMethods(doc, bodies)
+	linkVariables(doc, bodies)
+	linkArguments(bodies)
+}
+
+// linkMethods fills info about calls between documented methods
+func linkMethods(doc *script.Document, bodies []*methodBody) {
+	for _, b := range bodies {
+		if b.Method == nil {
+			continue
+		}
+
+		for _, cmd := range b.Commands() {
+			callee := doc.FindMethod(cmd)
+
+			// Recursive calls are not shown in call graph
+			if callee == nil || callee == b.Method {
+				continue
+			}
+
+			if !slices.Contains(b.Method.Calls, callee.Name) {
+				b.Method.Calls = append(b.Method.Calls, callee.Name)
+			}
+
+			if !slices.Contains(callee.CalledBy, b.Method.Name) {
+				callee.CalledBy = append(callee.CalledBy, b.Method.Name)
+			}
+		}
+	}
+}
+
+// linkVariables fills info about documented global variables and constants
+// read and modified by methods
+func linkVariables(doc *script.Document, bodies []*methodBody) {
+	for _, b := range bodies {
+		if b.Method == nil {
+			continue
+		}
+
+		reads, writes := b.Variables()
+
+		for _, name := range reads {
+			if doc.FindVariable(name) != nil && !hasVariableRef(b.Method.Reads, name) {
+				b.Method.Reads = append(b.Method.Reads, &script.VariableRef{Name: name, IsDetected: true})
+			}
+		}
+
+		for _, name := range writes {
+			if doc.FindVariable(name) != nil && !hasVariableRef(b.Method.Modifies, name) {
+				b.Method.Modifies = append(b.Method.Modifies, &script.VariableRef{Name: name, IsDetected: true})
+			}
+		}
+	}
+}
+
+// linkArguments sets names of documented arguments without names using
+// names of local variables with arguments values
+func linkArguments(bodies []*methodBody) {
+	for _, b := range bodies {
+		if b.Method == nil {
+			continue
+		}
+
+		var usage *paramsUsage
+
+		for _, arg := range b.Method.Arguments {
+			if arg.Name != "" || arg.IsMultiple() {
+				continue
+			}
+
+			if usage == nil {
+				usage = b.Usage()
+			}
+
+			arg.Name = usage.Names[arg.From]
+		}
+	}
+}
+
+// isDocumentedPosition returns true if method has documented argument for
+// given position
+func isDocumentedPosition(m *script.Method, index int) bool {
+	for _, arg := range m.Arguments {
+		if arg.HasPosition(index) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isUsedArgument returns true if any position of argument is used in method body
+func isUsedArgument(arg *script.Argument, usage *paramsUsage) bool {
+	for index := range usage.Params {
+		if arg.HasPosition(index) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasVariableRef returns true if slice contains reference to variable with
+// given name
+func hasVariableRef(refs []*script.VariableRef, name string) bool {
+	for _, r := range refs {
+		if r.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseVarRef parses reference to variable started with $ and returns
+// variable name
+func parseVarRef(line string, i int) string {
+	expr := line[i+1:]
+
+	if strings.HasPrefix(expr, "{") {
+		expr = strings.TrimPrefix(strings.TrimPrefix(expr[1:], "#"), "!")
+	}
+
+	return varNameRegExp.FindString(expr)
+}
+
+// parseParamRef parses reference to positional parameter started with $ and
+// returns its index (0 for all parameters)
+func parseParamRef(line string, i int) (int, bool) {
+	c := byteAt(line, i+1)
+
+	switch {
+	case c >= '1' && c <= '9':
+		return int(c - '0'), true
+	case c == '@', c == '*':
+		return 0, true
+	case c != '{':
+		return 0, false
+	}
+
+	expr := line[i+2:]
+
+	// ${#1} (length of parameter)
+	if strings.HasPrefix(expr, "#") && len(expr) > 1 && expr[1] != '}' {
+		expr = expr[1:]
+	}
+
+	switch byteAt(expr, 0) {
+	case '@', '*':
+		return 0, true
+	}
+
+	end := strings.IndexFunc(expr, func(r rune) bool { return r < '0' || r > '9' })
+
+	if end <= 0 {
+		return 0, false
+	}
+
+	index, err := strconv.Atoi(expr[:end])
+
+	return index, err == nil && index > 0
+}
+
+// applyShift applies shift to counter and returns false if shift can't be
+// tracked
+func applyShift(line string, loc []int, loops int, shifted *int) bool {
+	if loops > 0 {
+		return false
+	}
+
+	if loc[2] == -1 {
+		*shifted++
+		return true
+	}
+
+	num, err := strconv.Atoi(line[loc[2]:loc[3]])
+
+	if err != nil {
+		return false
+	}
+
+	*shifted += num
+
+	return true
+}
+
+// isCommandName returns true if given word looks like command name
+func isCommandName(word string) bool {
+	if strings.Contains(word, "=") || strings.ContainsAny(word, "$/\"'<>*?#[") {
+		return false
+	}
+
+	return word != "" && word[0] != '-'
+}
+
+// stripComment removes trailing comment from line of code
+func stripComment(line string) string {
+	var quote byte
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '\\':
+			i++
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '#' && isWordStart(line, i):
+			return line[:i]
+		}
+	}
+
+	return line
+}
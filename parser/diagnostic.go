@@ -0,0 +1,371 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Severity contains diagnostic severity
+type Severity uint8
+
+const (
+	SEVERITY_NOTICE  Severity = 1
+	SEVERITY_WARNING Severity = 2
+	SEVERITY_ERROR   Severity = 3
+)
+
+// Rule IDs
+const (
+	RULE_OPEN_ERROR         = "open-error"
+	RULE_SCAN_ERROR         = "scan-error"
+	RULE_MALFORMED_ARGUMENT = "malformed-argument"
+	RULE_DUPLICATE_ARGUMENT = "duplicate-argument"
+	RULE_EMPTY_EXAMPLE      = "empty-example"
+	RULE_UNKNOWN_TYPE       = "unknown-type"
+	RULE_MISSING_DOCS       = "missing-docs"
+	RULE_MISSING_DESC       = "missing-description"
+
+	RULE_UNDOCUMENTED_ARGUMENT = "undocumented-argument"
+	RULE_UNUSED_ARGUMENT       = "unused-argument"
+	RULE_MISSING_WILDCARD      = "missing-wildcard"
+
+	RULE_DYNAMIC_SOURCE = "dynamic-source"
+	RULE_MISSING_SOURCE = "missing-source"
+
+	RULE_UNKNOWN_TAG = "unknown-tag"
+
+	RULE_INVALID_DEFAULT = "invalid-default"
+	RULE_INVALID_CODE    = "invalid-code"
+
+	RULE_UNKNOWN_REFERENCE = "unknown-reference"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Diagnostic contains info about problem found in script
+type Diagnostic struct {
+	File     string   `json:"file"`     // Path to script
+	Line     int      `json:"line"`     // Line number (1-based)
+	Column   int      `json:"column"`   // Column number (1-based)
+	Severity Severity `json:"severity"` // Severity
+	Rule     string   `json:"rule"`     // Rule ID
+	Message  string   `json:"message"`  // Message
+	Entity   string   `json:"entity"`   // Name of related entity
+}
+
+// Diagnostics is a slice with diagnostics
+type Diagnostics []*Diagnostic
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// LinePos contains position of comment line in script
+type LinePos struct {
+	Line   int // Line number
+	Column int // Column of comment text
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// String returns severity name
+func (s Severity) String() string {
+	switch s {
+	case SEVERITY_NOTICE:
+		return "notice"
+	case SEVERITY_WARNING:
+		return "warning"
+	case SEVERITY_ERROR:
+		return "error"
+	}
+
+	return "unknown"
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Error returns diagnostic as a string in compiler style
+func (d *Diagnostic) Error() string {
+	if d == nil {
+		return ""
+	}
+
+	if d.Line == 0 {
+		return fmt.Sprintf("%s: %s: %s [%s]", d.File, d.Severity, d.Message, d.Rule)
+	}
+
+	return fmt.Sprintf(
+		"%s:%d:%d: %s: %s [%s]",
+		d.File, d.Line, d.Column, d.Severity, d.Message, d.Rule,
+	)
+}
+
+// IsError returns true if diagnostic has error severity
+func (d *Diagnostic) IsError() bool {
+	return d != nil && d.Severity == SEVERITY_ERROR
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IsEmpty returns true if there are no diagnostics
+func (d Diagnostics) IsEmpty() bool {
+	return len(d) == 0
+}
+
+// HasErrors returns true if there is at least one diagnostic with error severity
+func (d Diagnostics) HasErrors() bool {
+	for _, dd := range d {
+		if dd.IsError() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newDiagnostic creates new diagnostic
+func newDiagnostic(pos LinePos, offset int, severity Severity, rule, message string) *Diagnostic {
+	return &Diagnostic{
+		Line:     pos.Line,
+		Column:   pos.Column + offset,
+		Severity: severity,
+		Rule:     rule,
+		Message:  message,
+	}
+}
+
+// newMissingDescDiagnostic creates diagnostic for entity without description
+func newMissingDescDiagnostic(name string, pos LinePos) *Diagnostic {
+	d := newDiagnostic(
+		pos, 0, SEVERITY_WARNING, RULE_MISSING_DESC,
+		fmt.Sprintf("Entity %s doesn't have description", name),
+	)
+
+	d.Entity = name
+
+	return d
+}
+
+// validateMethodComment checks method comment and returns slice with
+// diagnostics
+func validateMethodComment(data []string, pos []LinePos) Diagnostics {
+	var result Diagnostics
+	var indexes []string
+	var inCodes bool
+
+	for index, line := range data {
+		switch {
+		case strings.HasPrefix(line, "Code:"):
+			inCodes = true
+			result = append(result, validateCodes(line[5:], pos[index], 5)...)
+		case inCodes && codeLineRegExp.MatchString(line):
+			result = append(result, validateCodes(line, pos[index], 0)...)
+		case !isContinuationLine(line) && strings.TrimSpace(line) != "":
+			inCodes = false
+		}
+
+		if strings.HasPrefix(line, "Example:") {
+			if strings.TrimSpace(strings.Join(data[index+1:], "")) == "" {
+				result = append(result, newDiagnostic(
+					pos[index], 0, SEVERITY_WARNING, RULE_EMPTY_EXAMPLE,
+					"Example block doesn't contain any code",
+				))
+			}
+
+			break // Example is last part of comment
+		}
+
+		if isTagLine(line) || !isArgumentLine(line) {
+			continue
+		}
+
+		loc := getArgumentLoc(line)
+		arg := parseArgumentComment(line)
+
+		switch {
+		case arg.From == 0:
+			result = append(result, newDiagnostic(
+				pos[index], loc[2], SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT,
+				"Argument index must be greater than 0",
+			))
+		case !arg.IsVariadic() && arg.To < arg.From:
+			result = append(result, newDiagnostic(
+				pos[index], loc[2], SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT,
+				fmt.Sprintf("Argument %s has invalid index range", arg.Index),
+			))
+		case arg.Desc == "":
+			result = append(result, newDiagnostic(
+				pos[index], loc[2], SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT,
+				fmt.Sprintf("Argument %s doesn't have description", arg.Index),
+			))
+		}
+
+		if slices.Contains(indexes, arg.Index) {
+			result = append(result, newDiagnostic(
+				pos[index], loc[2], SEVERITY_ERROR, RULE_DUPLICATE_ARGUMENT,
+				fmt.Sprintf("Argument %s is documented more than once", arg.Index),
+			))
+		}
+
+		indexes = append(indexes, arg.Index)
+
+		result = append(result, validateArgumentTypes(line[loc[6]:], pos[index], loc[6])...)
+		result = append(result, validateArgumentConstraints(arg, line[loc[6]:], pos[index], loc[6])...)
+	}
+
+	return result
+}
+
+// validateCodes checks that exit codes from "Code:" record are in range 0..255
+func validateCodes(line string, pos LinePos, offset int) Diagnostics {
+	var result Diagnostics
+
+	for _, loc := range codeRegExp.FindAllStringSubmatchIndex(line, -1) {
+		if d := validateCode(line[loc[2]:loc[3]], pos, offset+loc[2]); d != nil {
+			result = append(result, d)
+		}
+	}
+
+	return result
+}
+
+// validateCode checks that exit code is in range 0..255
+func validateCode(code string, pos LinePos, offset int) *Diagnostic {
+	value, err := strconv.Atoi(code)
+
+	if err == nil && value <= 255 {
+		return nil
+	}
+
+	return newDiagnostic(
+		pos, offset, SEVERITY_WARNING, RULE_INVALID_CODE,
+		fmt.Sprintf("Exit code %s is out of range 0..255", code),
+	)
+}
+
+// validateVariableComment checks variable comment and returns slice with
+// diagnostics
+func validateVariableComment(data []string, pos []LinePos) Diagnostics {
+	var result Diagnostics
+
+	for index, line := range data {
+		if isTypeComment(line) || isTagLine(line) {
+			continue
+		}
+
+		if d := validateTypeMarker(line, pos[index], 0); d != nil {
+			result = append(result, d)
+		}
+	}
+
+	return result
+}
+
+// validateArgumentTypes checks type markers in argument description
+func validateArgumentTypes(desc string, pos LinePos, offset int) Diagnostics {
+	var result Diagnostics
+
+	for _, word := range strings.Split(desc, " ") {
+		if argTypeRegExp.MatchString(word) {
+			typeName := strings.Trim(word, "()")
+
+			if getTypeByName(typeName) == script.VAR_TYPE_UNKNOWN {
+				result = append(result, newDiagnostic(
+					pos, offset, SEVERITY_WARNING, RULE_UNKNOWN_TYPE,
+					fmt.Sprintf("Unknown type marker %q", typeName),
+				))
+			}
+		}
+
+		offset += len(word) + 1
+	}
+
+	return result
+}
+
+// validateArgumentConstraints checks default value and constraints of argument
+func validateArgumentConstraints(arg *script.Argument, desc string, pos LinePos, offset int) Diagnostics {
+	var result Diagnostics
+
+	for _, loc := range argRangeRegExp.FindAllStringSubmatchIndex(desc, -1) {
+		typeName := desc[loc[2]:loc[3]]
+
+		if getTypeByName(typeName) == script.VAR_TYPE_UNKNOWN {
+			result = append(result, newDiagnostic(
+				pos, offset+loc[0], SEVERITY_WARNING, RULE_UNKNOWN_TYPE,
+				fmt.Sprintf("Unknown type marker %q", typeName),
+			))
+		}
+	}
+
+	if arg.Min != nil && arg.Max != nil && *arg.Min > *arg.Max {
+		result = append(result, newDiagnostic(
+			pos, offset, SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT,
+			fmt.Sprintf("Argument %s has invalid range %s", arg.Index, arg.Range()),
+		))
+	}
+
+	if !arg.HasDefault() {
+		return result
+	}
+
+	if arg.HasEnum() && !slices.Contains(arg.Enum, arg.Default) {
+		result = append(result, newDiagnostic(
+			pos, offset, SEVERITY_WARNING, RULE_INVALID_DEFAULT,
+			fmt.Sprintf("Default value %q of argument %s is not in the list of allowed values", arg.Default, arg.Index),
+		))
+	}
+
+	if arg.IsNumber() {
+		value, err := strconv.Atoi(arg.Default)
+
+		switch {
+		case err != nil:
+			result = append(result, newDiagnostic(
+				pos, offset, SEVERITY_WARNING, RULE_INVALID_DEFAULT,
+				fmt.Sprintf("Default value %q of argument %s is not a number", arg.Default, arg.Index),
+			))
+		case (arg.Min != nil && value < *arg.Min) || (arg.Max != nil && value > *arg.Max):
+			result = append(result, newDiagnostic(
+				pos, offset, SEVERITY_WARNING, RULE_INVALID_DEFAULT,
+				fmt.Sprintf("Default value %q of argument %s is out of range %s", arg.Default, arg.Index, arg.Range()),
+			))
+		}
+	}
+
+	return result
+}
+
+// validateTypeMarker checks type marker at the end of the line
+func validateTypeMarker(line string, pos LinePos, offset int) *Diagnostic {
+	line = strings.TrimRight(line, " ")
+
+	if !typeMarkerRegExp.MatchString(line) {
+		return nil
+	}
+
+	loc := typeMarkerRegExp.FindStringSubmatchIndex(line)
+	typeName := line[loc[2]:loc[3]]
+
+	if getTypeByName(typeName) != script.VAR_TYPE_UNKNOWN {
+		return nil
+	}
+
+	return newDiagnostic(
+		pos, offset+loc[0], SEVERITY_WARNING, RULE_UNKNOWN_TYPE,
+		fmt.Sprintf("Unknown type marker %q", typeName),
+	)
+}
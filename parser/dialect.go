@@ -0,0 +1,246 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Supported dialects
+const (
+	DIALECT_AUTO      = "auto"
+	DIALECT_DEFAULT   = "default"
+	DIALECT_ANNOTATED = "annotated"
+	DIALECT_GOOGLE    = "google"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Dialect is convention used for writing documentation comments
+type Dialect interface {
+	// Name returns name of dialect
+	Name() string
+
+	// Detect returns true if given comment lines are written in dialect
+	Detect(comments []string) bool
+
+	// ParseAbout parses comment with info about script
+	ParseAbout(data []string) []string
+
+	// ParseMethod parses method comment and returns method info or nil if
+	// method must be ignored
+	ParseMethod(name string, data []string) *script.Method
+
+	// ParseVariable parses variable comment and returns variable info or nil if
+	// variable must be ignored
+	ParseVariable(name, value string, data []string) *script.Variable
+}
+
+// Validator is optional interface of dialect which can check comments
+type Validator interface {
+	// ValidateMethod checks method comment and returns slice with diagnostics
+	ValidateMethod(data []string, pos []LinePos) Diagnostics
+
+	// ValidateVariable checks variable comment and returns slice with
+	// diagnostics
+	ValidateVariable(data []string, pos []LinePos) Diagnostics
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// defaultDialect is native dialect with records (1:, Code:, Echo:…)
+type defaultDialect struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// dialects contains all supported dialects, the first one is used if no
+// other dialect is detected
+var dialects = []Dialect{
+	&defaultDialect{},
+	&annotatedDialect{},
+	&googleDialect{},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetDialect returns supported dialect with given name, empty name or "auto"
+// returns nil (dialect is auto-detected for every script)
+func GetDialect(name string) (Dialect, error) {
+	if name == "" || name == DIALECT_AUTO {
+		return nil, nil
+	}
+
+	d := findDialect(name)
+
+	if d == nil {
+		return nil, fmt.Errorf("Unknown dialect %q", name)
+	}
+
+	return d, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Name returns name of dialect
+func (d *defaultDialect) Name() string {
+	return DIALECT_DEFAULT
+}
+
+// Detect returns true if given comment lines are written in dialect
+func (d *defaultDialect) Detect(comments []string) bool {
+	return true
+}
+
+// ParseAbout parses comment with info about script
+func (d *defaultDialect) ParseAbout(data []string) []string {
+	return getCleanData(data)
+}
+
+// ParseMethod parses method comment and returns method info
+func (d *defaultDialect) ParseMethod(name string, data []string) *script.Method {
+	return parseMethodComment(name, data)
+}
+
+// ParseVariable parses variable comment and returns variable info
+func (d *defaultDialect) ParseVariable(name, value string, data []string) *script.Variable {
+	return parseVariableComment(name, value, data)
+}
+
+// ValidateMethod checks method comment and returns slice with diagnostics
+func (d *defaultDialect) ValidateMethod(data []string, pos []LinePos) Diagnostics {
+	return append(validateMethodComment(data, pos), validateTags(data, pos)...)
+}
+
+// ValidateVariable checks variable comment and returns slice with diagnostics
+func (d *defaultDialect) ValidateVariable(data []string, pos []LinePos) Diagnostics {
+	return append(validateVariableComment(data, pos), validateTags(data, pos)...)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// findDialect returns dialect with given name
+func findDialect(name string) Dialect {
+	for _, d := range dialects {
+		if strings.EqualFold(d.Name(), name) {
+			return d
+		}
+	}
+
+	return nil
+}
+
+// detectDialect returns dialect used in script with given data, dialect is
+// detected by comments of documented entities
+func detectDialect(data []byte, sh *shell) Dialect {
+	return detectBlocksDialect(getEntityComments(data, sh))
+}
+
+// detectBlocksDialect returns dialect used by most of given comment blocks
+// (dialect of the first block wins a tie)
+func detectBlocksDialect(blocks [][]string) Dialect {
+	result := dialects[0]
+	counts := make(map[Dialect]int)
+
+	for _, block := range blocks {
+		d := detectBlockDialect(block)
+		counts[d]++
+
+		if counts[d] > counts[result] {
+			result = d
+		}
+	}
+
+	return result
+}
+
+// detectBlockDialect returns dialect of given comment block
+func detectBlockDialect(block []string) Dialect {
+	for _, d := range dialects[1:] {
+		if d.Detect(block) {
+			return d
+		}
+	}
+
+	return dialects[0]
+}
+
+// getEntityComments returns comment blocks placed directly above definitions
+// of methods and global variables
+func getEntityComments(data []byte, sh *shell) [][]string {
+	var result [][]string
+	var buffer []string
+
+	lx := &lexer{}
+
+	for index, line := range strings.Split(string(data), "\n") {
+		if lx.InHeredoc() || lx.IsOpen() {
+			lx.Feed(line)
+			continue
+		}
+
+		indent := len(line)
+		line = strings.TrimLeft(strings.TrimRight(line, "\r"), " \t")
+		indent -= len(line)
+
+		switch {
+		case index == 0 && strings.HasPrefix(line, "#!"):
+			continue
+
+		case line == "":
+			buffer = nil
+
+		case line[0] == '#':
+			buffer = append(buffer, strings.TrimLeft(line[1:], " \t"))
+
+		default:
+			lx.Feed(line)
+
+			// Variables defined in method bodies are not documented
+			t, _, _, _ := parseEntity(line, sh)
+
+			if t == ENT_TYPE_METHOD || (t != ENT_TYPE_UNKNOWN && indent == 0) {
+				if buffer != nil {
+					result = append(result, buffer)
+				}
+			}
+
+			buffer = nil
+		}
+	}
+
+	return result
+}
+
+// validateMethod checks method comment if dialect supports validation
+func validateMethod(d Dialect, data []string, pos []LinePos) Diagnostics {
+	if v, ok := d.(Validator); ok {
+		return v.ValidateMethod(data, pos)
+	}
+
+	return nil
+}
+
+// validateVariable checks variable comment if dialect supports validation
+func validateVariable(d Dialect, data []string, pos []LinePos) Diagnostics {
+	if v, ok := d.(Validator); ok {
+		return v.ValidateVariable(data, pos)
+	}
+
+	return nil
+}
+
+// isIgnored returns true if entity comment marked as private
+func isIgnored(data []string) bool {
+	return len(data) == 0 || slices.Contains(ignoreTags, strings.TrimRight(data[0], " "))
+}
@@ -0,0 +1,400 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Blocks of Google Shell Style Guide comments
+const (
+	GOOGLE_GLOBALS   = "Globals"
+	GOOGLE_ARGUMENTS = "Arguments"
+	GOOGLE_OUTPUTS   = "Outputs"
+	GOOGLE_RETURNS   = "Returns"
+	GOOGLE_EXAMPLE   = "Example"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// googleDialect is dialect from Google Shell Style Guide with blocks Globals:,
+// Arguments:, Outputs: and Returns:
+type googleDialect struct{}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	googleBlockRegExp  = regexp.MustCompile(`^(Globals|Arguments|Outputs|Returns|Examples?):[ \t]*(.*)$`)
+	googleDetectRegExp = regexp.MustCompile(`^(Arguments|Outputs|Returns):`)
+	googleArgRegExp    = regexp.MustCompile(`^\$([0-9]{1,}|@|\*)(?:[ \t]*[-:][ \t]*|[ \t]+|$)(.*)$`)
+	googleVarRegExp    = regexp.MustCompile(`^\$?\{?([a-zA-Z_][a-zA-Z0-9_]*)\}?(?:[ \t]*[-:][ \t]*|[ \t]+|$)(.*)$`)
+	googleCodeRegExp   = regexp.MustCompile(`^([0-9]{1,})(?:[ \t]*[-:][ \t]*|[ \t]+|$)(.*)$`)
+	googleNoneRegExp   = regexp.MustCompile(`^(?i)none\.?$`)
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Name returns name of dialect
+func (d *googleDialect) Name() string {
+	return DIALECT_GOOGLE
+}
+
+// Detect returns true if given comment lines are written in dialect
+func (d *googleDialect) Detect(comments []string) bool {
+	for _, line := range comments {
+		if googleDetectRegExp.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseAbout parses comment with info about script
+func (d *googleDialect) ParseAbout(data []string) []string {
+	return getCleanData(data)
+}
+
+// ParseMethod parses method comment and returns method info
+func (d *googleDialect) ParseMethod(name string, data []string) *script.Method {
+	if isIgnored(data) {
+		return nil
+	}
+
+	data, tags := parseTags(data)
+
+	method := &script.Method{Name: name, Tags: tags}
+
+	var desc []string
+	var skip int
+
+	for index, line := range data {
+		// Skip lines of multi-line block
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		if !googleBlockRegExp.MatchString(line) {
+			desc = append(desc, line)
+			continue
+		}
+
+		bd := googleBlockRegExp.FindStringSubmatch(line)
+
+		if bd[1] == GOOGLE_EXAMPLE || bd[1] == GOOGLE_EXAMPLE+"s" {
+			method.Example, skip = getExampleLines(bd[2], data[index+1:])
+			continue
+		}
+
+		var items [][]string
+
+		items, skip = getGoogleItems(bd[2], data[index+1:])
+
+		switch bd[1] {
+		case GOOGLE_GLOBALS:
+			method.Reads = append(method.Reads, parseGoogleGlobals(items)...)
+		case GOOGLE_ARGUMENTS:
+			method.Arguments = append(method.Arguments, parseGoogleArguments(items)...)
+		case GOOGLE_OUTPUTS:
+			parseGoogleOutputs(method, items)
+		case GOOGLE_RETURNS:
+			desc = append(desc, parseGoogleReturns(method, items)...)
+		}
+	}
+
+	method.Desc = getCleanData(trimEmptyLines(desc))
+
+	return method
+}
+
+// ParseVariable parses variable comment and returns variable info
+func (d *googleDialect) ParseVariable(name, value string, data []string) *script.Variable {
+	return parseVariableComment(name, value, data)
+}
+
+// ValidateMethod checks method comment and returns slice with diagnostics
+func (d *googleDialect) ValidateMethod(data []string, pos []LinePos) Diagnostics {
+	var result Diagnostics
+	var indexes []string
+	var skip int
+
+	next := 1
+
+	for index, line := range data {
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		if !googleBlockRegExp.MatchString(line) {
+			continue
+		}
+
+		bd := googleBlockRegExp.FindStringSubmatch(line)
+
+		if bd[1] == GOOGLE_EXAMPLE || bd[1] == GOOGLE_EXAMPLE+"s" {
+			break // Example is last part of comment
+		}
+
+		var items [][]string
+		var heads []int
+
+		items, heads, skip = readGoogleItems(bd[2], data[index+1:])
+
+		for i, item := range items {
+			// Item can be defined on the same line with block name
+			itemPos, offset := pos[index], strings.Index(line, item[0])
+
+			if heads[i] != -1 {
+				itemLine := data[index+1+heads[i]]
+				itemPos, offset = pos[index+1+heads[i]], strings.Index(itemLine, item[0])
+			}
+
+			switch bd[1] {
+			case GOOGLE_ARGUMENTS:
+				argument, diags := validateGoogleArgument(item[0], itemPos, offset, next)
+				result = append(result, diags...)
+
+				if slices.Contains(indexes, argument.Index) {
+					result = append(result, newDiagnostic(
+						itemPos, offset, SEVERITY_ERROR, RULE_DUPLICATE_ARGUMENT,
+						fmt.Sprintf("Argument %s is documented more than once", argument.Index),
+					))
+				}
+
+				indexes = append(indexes, argument.Index)
+
+				if !argument.IsVariadic() {
+					next = max(argument.From, argument.To) + 1
+				}
+
+			case GOOGLE_RETURNS:
+				if googleCodeRegExp.MatchString(item[0]) {
+					if d := validateCode(googleCodeRegExp.FindStringSubmatch(item[0])[1], itemPos, offset); d != nil {
+						result = append(result, d)
+					}
+				}
+			}
+		}
+	}
+
+	return append(result, validateTags(data, pos)...)
+}
+
+// ValidateVariable checks variable comment and returns slice with diagnostics
+func (d *googleDialect) ValidateVariable(data []string, pos []LinePos) Diagnostics {
+	return append(validateVariableComment(data, pos), validateTags(data, pos)...)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getGoogleItems returns items of block (every item is a slice with item line
+// and following lines with bigger indentation) and number of used lines
+func getGoogleItems(value string, data []string) ([][]string, int) {
+	result, _, used := readGoogleItems(value, data)
+	return result, used
+}
+
+// readGoogleItems returns items of block, indexes of lines with the first
+// line of every item (-1 for item defined on the line with block name) and
+// number of used lines
+func readGoogleItems(value string, data []string) ([][]string, []int, int) {
+	var result [][]string
+	var heads []int
+
+	if value != "" && !googleNoneRegExp.MatchString(value) {
+		result = append(result, []string{value})
+		heads = append(heads, -1)
+	}
+
+	indent := -1
+
+	for index, line := range data {
+		text := strings.TrimLeft(line, " \t")
+		size := len(line) - len(text)
+
+		switch {
+		case text == "":
+			if index+1 >= len(data) || !isContinuationLine(data[index+1]) {
+				return result, heads, index
+			}
+
+			if len(result) != 0 {
+				result[len(result)-1] = append(result[len(result)-1], "")
+			}
+
+			continue
+
+		case size == 0:
+			return result, heads, index
+		}
+
+		if indent == -1 {
+			indent = size
+		}
+
+		switch {
+		case size > indent && len(result) != 0:
+			result[len(result)-1] = append(result[len(result)-1], strings.TrimRight(text, " "))
+		case !googleNoneRegExp.MatchString(strings.TrimRight(text, " ")):
+			result = append(result, []string{strings.TrimRight(text, " ")})
+			heads = append(heads, index)
+		}
+	}
+
+	return result, heads, len(data)
+}
+
+// parseGoogleGlobals parses items of "Globals:" block
+func parseGoogleGlobals(items [][]string) []*script.VariableRef {
+	var result []*script.VariableRef
+
+	for _, item := range items {
+		if !googleVarRegExp.MatchString(item[0]) {
+			continue
+		}
+
+		vd := googleVarRegExp.FindStringSubmatch(item[0])
+		desc := strings.Join(append([]string{vd[2]}, item[1:]...), " ")
+
+		result = append(result, &script.VariableRef{
+			Name: vd[1],
+			Desc: strings.TrimSpace(desc),
+		})
+	}
+
+	return result
+}
+
+// parseGoogleArguments parses items of "Arguments:" block, items without
+// index ($1, $@) describe arguments in order of definition
+func parseGoogleArguments(items [][]string) []*script.Argument {
+	var result []*script.Argument
+
+	next := 1
+
+	for _, item := range items {
+		index, desc := strconv.Itoa(next), item[0]
+
+		if googleArgRegExp.MatchString(item[0]) {
+			ad := googleArgRegExp.FindStringSubmatch(item[0])
+			index, desc = ad[1], ad[2]
+		}
+
+		if index == "@" {
+			index = "*"
+		}
+
+		argument := parseArgumentComment(index + ": " + desc)
+		appendArgumentDesc(argument, item[1:])
+
+		if !argument.IsVariadic() {
+			next = max(argument.From, argument.To) + 1
+		}
+
+		result = append(result, argument)
+	}
+
+	return result
+}
+
+// validateGoogleArgument checks item of "Arguments:" block and returns parsed
+// argument and diagnostics, items without index describe argument with given
+// position
+func validateGoogleArgument(item string, pos LinePos, offset, next int) (*script.Argument, Diagnostics) {
+	var result Diagnostics
+
+	index, desc, descOffset := strconv.Itoa(next), item, 0
+
+	if googleArgRegExp.MatchString(item) {
+		loc := googleArgRegExp.FindStringSubmatchIndex(item)
+		index, desc, descOffset = item[loc[2]:loc[3]], item[loc[4]:loc[5]], loc[4]
+	}
+
+	if index == "@" {
+		index = "*"
+	}
+
+	argument := parseArgumentComment(index + ": " + desc)
+
+	switch {
+	case argument.From == 0:
+		result = append(result, newDiagnostic(
+			pos, offset, SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT,
+			"Argument index must be greater than 0",
+		))
+	case argument.Desc == "":
+		result = append(result, newDiagnostic(
+			pos, offset, SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT,
+			fmt.Sprintf("Argument %s doesn't have description", argument.Index),
+		))
+	}
+
+	result = append(result, validateArgumentTypes(desc, pos, offset+descOffset)...)
+	result = append(result, validateArgumentConstraints(argument, desc, pos, offset+descOffset)...)
+
+	return argument, result
+}
+
+// parseGoogleOutputs parses items of "Outputs:" block, items which mention
+// stderr are added to stderr info and all others to echo info
+func parseGoogleOutputs(method *script.Method, items [][]string) {
+	var echo []string
+
+	for _, item := range items {
+		if strings.Contains(strings.ToLower(item[0]), "stderr") {
+			method.Stderr = append(method.Stderr, item...)
+		} else {
+			echo = append(echo, item...)
+		}
+	}
+
+	if len(echo) != 0 {
+		method.ResultEcho = parseVariableComment("", "", echo)
+	}
+}
+
+// parseGoogleReturns parses items of "Returns:" block and returns lines which
+// don't describe exit codes
+func parseGoogleReturns(method *script.Method, items [][]string) []string {
+	var result []string
+
+	last := -1
+
+	for _, item := range items {
+		if !googleCodeRegExp.MatchString(item[0]) {
+			if last != -1 {
+				method.ResultCodes[last] += "\n" + strings.Join(item, "\n")
+			} else {
+				result = append(result, "", "Returns: "+strings.Join(item, " "))
+			}
+
+			continue
+		}
+
+		cd := googleCodeRegExp.FindStringSubmatch(item[0])
+		last, _ = strconv.Atoi(cd[1])
+
+		if method.ResultCodes == nil {
+			method.ResultCodes = map[int]string{}
+		}
+
+		method.ResultCodes[last] = strings.Join(append([]string{cd[2]}, item[1:]...), "\n")
+	}
+
+	return result
+}
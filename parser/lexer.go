@@ -0,0 +1,345 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Context types
+const (
+	_CTX_SINGLE_QUOTE byte = '\'' // '...'
+	_CTX_ANSI_QUOTE   byte = 'c'  // $'...'
+	_CTX_DOUBLE_QUOTE byte = '"'  // "..."
+	_CTX_BACKTICK     byte = '`'  // `...`
+	_CTX_SUBSHELL     byte = '('  // $(...), <(...), =(...)
+	_CTX_ARITHMETIC   byte = 'a'  // $((...)), ((...))
+	_CTX_PARAM        byte = '{'  // ${...}
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// lexer tracks shell quoting context between lines
+type lexer struct {
+	stack    []byte     // Stack with open contexts
+	heredocs []*heredoc // Heredocs with pending bodies
+	cont     bool       // Line ends with backslash
+
+	onDollar func(line string, i int) // Handler for expansions
+}
+
+// heredoc contains info about here-document
+type heredoc struct {
+	Delim     string // Delimiter
+	StripTabs bool   // Leading tabs are stripped (<<-)
+	IsQuoted  bool   // Delimiter is quoted, body is not expanded
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Feed processes next line of script
+func (l *lexer) Feed(line string) {
+	if l.InHeredoc() {
+		l.ScanHeredoc(line)
+	} else {
+		l.Scan(line)
+	}
+}
+
+// IsExpanding returns true if next line is part of here-document body with
+// expansions
+func (l *lexer) IsExpanding() bool {
+	return l.InHeredoc() && !l.heredocs[0].IsQuoted
+}
+
+// IsOpen returns true if previous line has unclosed quotes, command substitution
+// or ends with backslash
+func (l *lexer) IsOpen() bool {
+	return len(l.stack) != 0 || l.cont
+}
+
+// InHeredoc returns true if next line is part of here-document body
+func (l *lexer) InHeredoc() bool {
+	return len(l.heredocs) != 0
+}
+
+// ScanHeredoc processes line of here-document body
+func (l *lexer) ScanHeredoc(line string) {
+	h := l.heredocs[0]
+
+	if h.StripTabs {
+		line = strings.TrimLeft(line, "\t")
+	}
+
+	if line == h.Delim {
+		l.heredocs = l.heredocs[1:]
+	}
+}
+
+// Scan processes line of code
+func (l *lexer) Scan(line string) {
+	l.scan(line, false, false)
+}
+
+// ScanValue processes line with assignment value (or continuation of value)
+// and returns index of the end of value. It returns -1 if value continues on
+// the next line.
+func (l *lexer) ScanValue(line string, first bool) int {
+	if l.InHeredoc() {
+		l.ScanHeredoc(line)
+		return -1
+	}
+
+	end := l.scan(line, true, first)
+
+	if end == -1 && !l.IsOpen() && !l.InHeredoc() {
+		return len(line)
+	}
+
+	return end
+}
+
+// scan processes line and returns index of the end of the word if value
+// mode is enabled
+func (l *lexer) scan(line string, value, first bool) int {
+	l.cont = false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		next := byteAt(line, i+1)
+
+		switch l.top() {
+		case _CTX_SINGLE_QUOTE:
+			if c == '\'' {
+				l.pop()
+			}
+
+			continue
+
+		case _CTX_ANSI_QUOTE:
+			switch c {
+			case '\\':
+				i++
+			case '\'':
+				l.pop()
+			}
+
+			continue
+
+		case _CTX_DOUBLE_QUOTE:
+			switch {
+			case c == '\\':
+				if next == 0 {
+					return -1
+				}
+
+				i++
+			case c == '"':
+				l.pop()
+			case c == '`':
+				l.push(_CTX_BACKTICK)
+			case c == '$':
+				i += l.scanDollar(line, i)
+			}
+
+			continue
+		}
+
+		switch {
+		case value && len(l.stack) == 0 && strings.IndexByte(" \t;&|", c) != -1:
+			return i // End of value
+
+		case value && first && i == 0 && c == '(':
+			l.push(_CTX_SUBSHELL) // Array
+
+		case value && first && i == 0 && c == '#':
+			continue // Value started with #, not a comment
+
+		case c == '\\':
+			if next == 0 {
+				l.cont = true
+				return -1
+			}
+
+			i++
+
+		case c == '\'':
+			l.push(_CTX_SINGLE_QUOTE)
+
+		case c == '"':
+			l.push(_CTX_DOUBLE_QUOTE)
+
+		case c == '`':
+			if l.top() == _CTX_BACKTICK {
+				l.pop()
+			} else {
+				l.push(_CTX_BACKTICK)
+			}
+
+		case c == '$':
+			i += l.scanDollar(line, i)
+
+		case c == '#' && isWordStart(line, i) && l.top() != _CTX_PARAM && l.top() != _CTX_ARITHMETIC:
+			return -1 // Comment
+
+		case c == '(' && next == '(' && isWordStart(line, i):
+			l.push(_CTX_ARITHMETIC)
+			i++
+
+		case c == '(' && (l.top() == _CTX_SUBSHELL || l.top() == _CTX_ARITHMETIC):
+			l.push(_CTX_SUBSHELL)
+
+		case c == '(' && i > 0 && strings.IndexByte("<>=", line[i-1]) != -1:
+			l.push(_CTX_SUBSHELL)
+
+		case c == ')' && l.top() == _CTX_SUBSHELL:
+			l.pop()
+
+		case c == ')' && next == ')' && l.top() == _CTX_ARITHMETIC:
+			l.pop()
+			i++
+
+		case c == '}' && l.top() == _CTX_PARAM:
+			l.pop()
+
+		case c == '<' && next == '<' && l.top() != _CTX_ARITHMETIC:
+			i += l.scanHeredoc(line, i)
+		}
+	}
+
+	return -1
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// scanDollar processes expansion started with $ and returns number of
+// consumed bytes
+func (l *lexer) scanDollar(line string, i int) int {
+	if l.onDollar != nil {
+		l.onDollar(line, i)
+	}
+
+	switch {
+	case byteAt(line, i+1) == '(' && byteAt(line, i+2) == '(':
+		l.push(_CTX_ARITHMETIC)
+		return 2
+	case byteAt(line, i+1) == '(':
+		l.push(_CTX_SUBSHELL)
+		return 1
+	case byteAt(line, i+1) == '{':
+		l.push(_CTX_PARAM)
+		return 1
+	case byteAt(line, i+1) == '\'' && l.top() != _CTX_DOUBLE_QUOTE:
+		l.push(_CTX_ANSI_QUOTE)
+		return 1
+	}
+
+	return 0
+}
+
+// scanHeredoc processes here-document operator and returns number of
+// consumed bytes
+func (l *lexer) scanHeredoc(line string, i int) int {
+	start := i
+	i += 2
+
+	// Here-string (<<<)
+	if byteAt(line, i) == '<' {
+		return 2
+	}
+
+	h := &heredoc{}
+
+	if byteAt(line, i) == '-' {
+		h.StripTabs = true
+		i++
+	}
+
+	for byteAt(line, i) == ' ' || byteAt(line, i) == '\t' {
+		i++
+	}
+
+	var delim strings.Builder
+
+WORD:
+	for ; i < len(line); i++ {
+		c := line[i]
+
+		switch c {
+		case ' ', '\t', ';', '|', '&', '<', '>', '(', ')':
+			break WORD
+		case '\\':
+			h.IsQuoted = true
+			continue
+		case '\'', '"':
+			h.IsQuoted = true
+			end := strings.IndexByte(line[i+1:], c)
+
+			if end == -1 {
+				delim.WriteString(line[i+1:])
+				i = len(line)
+				break WORD
+			}
+
+			delim.WriteString(line[i+1 : i+1+end])
+			i += end + 1
+		default:
+			delim.WriteByte(c)
+		}
+	}
+
+	if delim.Len() == 0 {
+		return i - start - 1
+	}
+
+	h.Delim = delim.String()
+	l.heredocs = append(l.heredocs, h)
+
+	return i - start - 1
+}
+
+// top returns current context
+func (l *lexer) top() byte {
+	if len(l.stack) == 0 {
+		return 0
+	}
+
+	return l.stack[len(l.stack)-1]
+}
+
+// push adds new context to stack
+func (l *lexer) push(ctx byte) {
+	l.stack = append(l.stack, ctx)
+}
+
+// pop removes current context from stack
+func (l *lexer) pop() {
+	l.stack = l.stack[:len(l.stack)-1]
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// byteAt returns byte with given index or 0 if index is out of range
+func byteAt(s string, i int) byte {
+	if i < 0 || i >= len(s) {
+		return 0
+	}
+
+	return s[i]
+}
+
+// isWordStart returns true if byte with given index is the first byte of word
+func isWordStart(line string, i int) bool {
+	if i == 0 {
+		return true
+	}
+
+	return strings.IndexByte(" \t;|&()", line[i-1]) != -1
+}
@@ -0,0 +1,77 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// link contains info about explicit reference ({@link name}) to entity
+type link struct {
+	Name string  // Name of referenced entity
+	File string  // Path to script with reference
+	Pos  LinePos // Position of reference
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// findLinks returns all explicit references from comment
+func findLinks(file string, data []string, pos []LinePos) []*link {
+	var result []*link
+
+	for index, line := range data {
+		if strings.HasPrefix(line, "Example:") {
+			break // Example is last part of comment
+		}
+
+		for _, loc := range script.LinkRegExp.FindAllStringSubmatchIndex(line, -1) {
+			result = append(result, &link{
+				Name: line[loc[2]:loc[3]],
+				File: file,
+				Pos:  LinePos{pos[index].Line, pos[index].Column + loc[0]},
+			})
+		}
+	}
+
+	return result
+}
+
+// findSectionLinks returns explicit references from section description, rest
+// is a part of comment which follows the description
+func findSectionLinks(file string, data []string, pos []LinePos, rest []string) []*link {
+	end := len(data) - len(rest)
+
+	return findLinks(file, data[:end], pos[:end])
+}
+
+// checkLinks returns diagnostics for references to unknown entities
+func checkLinks(doc *script.Document, links []*link) Diagnostics {
+	var result Diagnostics
+
+	for _, l := range links {
+		if doc.LinkOf(l.Name) != "" {
+			continue
+		}
+
+		d := newDiagnostic(
+			l.Pos, 0, SEVERITY_WARNING, RULE_UNKNOWN_REFERENCE,
+			fmt.Sprintf("Reference to unknown entity %s", l.Name),
+		)
+
+		d.File, d.Entity = l.File, l.Name
+
+		result = append(result, d)
+	}
+
+	return result
+}
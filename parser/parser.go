@@ -9,15 +9,16 @@ package parser
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 
-	"github.com/essentialkaos/ek/v13/errors"
 	"github.com/essentialkaos/ek/v13/strutil"
 
 	"github.com/essentialkaos/shdoc/script"
@@ -25,6 +26,13 @@ import (
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// Options contains options for parsing scripts
+type Options struct {
+	Dialect Dialect // Comments dialect (auto-detected for every script if nil)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 type EntityType uint8
 
 const (
@@ -37,12 +45,24 @@ const (
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 var (
-	methodRegExp      = regexp.MustCompile(`^([a-zA-Z0-9._]{1,})\(\)`)
+	methodRegExp      = regexp.MustCompile(`^([a-zA-Z0-9._]{1,})[ \t]*\([ \t]*\)`)
+	funcRegExp        = regexp.MustCompile(`^function[ \t]+([a-zA-Z0-9._]{1,})[ \t]*(\([ \t]*\))?[ \t]*(\{.*|#.*)?$`)
 	variableRegExp    = regexp.MustCompile(`^([a-zA-Z0-9_.\[\]]{1,})=(.*)$`)
+	declRegExp        = regexp.MustCompile(`^(declare|typeset|readonly|export|local|integer|float)((?:[ \t]+[-+][a-zA-Z]{1,})*)[ \t]+([a-zA-Z0-9_]{1,})(=(.*))?$`)
 	constantRegExp    = regexp.MustCompile(`^[A-Z0-9_]{1,}$`)
 	numberRegExp      = regexp.MustCompile(`^[0-9]{1,}$`)
-	typeCommentRegExp = regexp.MustCompile(`^(.*) \((Boolean|String|Number)\)`)
-	methodArgRegExp   = regexp.MustCompile(`([0-9]{1,}|\*):[ ]{0,}(.*)`)
+	typeCommentRegExp = regexp.MustCompile(`^(.*) \(([A-Z][a-zA-Z]{1,})\)`)
+	methodArgRegExp   = regexp.MustCompile(`([0-9]{1,}(?:\.\.(?:[0-9]{1,}|\*)|\+)?|\*):[ ]{0,}(.*)`)
+	namedArgRegExp    = regexp.MustCompile(`^([0-9]{1,}(?:\.\.(?:[0-9]{1,}|\*)|\+)?|\*)[ \t]+([a-zA-Z_][a-zA-Z0-9_]*):[ ]{0,}(.*)`)
+	argNameRegExp     = regexp.MustCompile(`^<([a-zA-Z_][a-zA-Z0-9_]*)>[ ]{0,}`)
+	argOptionalRegExp = regexp.MustCompile(`\[(?:Optional(?:=([^\]]*))?|Default:[ ]*([^\]]*))\]`)
+	argRangeRegExp    = regexp.MustCompile(`\(([A-Z][a-zA-Z]{1,})[ ]+(-?[0-9]*)\.\.(-?[0-9]*)\)`)
+	argEnumRegExp     = regexp.MustCompile(`\(Enum:[ ]*([^)]*)\)`)
+	arrayKeyRegExp    = regexp.MustCompile(`^\[([^\]]{1,})\]=(.*)$`)
+	typeMarkerRegExp  = regexp.MustCompile(`\(([A-Z][a-zA-Z]{1,})\)$`)
+	codeRegExp        = regexp.MustCompile(`(?:^|,)[ \t]*([0-9]{1,})[ \t]+-[ \t]+`)
+	codeLineRegExp    = regexp.MustCompile(`^[ \t]*[0-9]{1,}[ \t]+-[ \t]+`)
+	argTypeRegExp     = regexp.MustCompile(`^\(([A-Z][a-zA-Z]{1,})\)$`)
 	negativeValRegexp = regexp.MustCompile(`^((N|n)one|(N|n)o(t|)|(F|f)alse)`)
 
 	shellcheckRegexp = regexp.MustCompile(`\# +shellcheck +disable\=`)
@@ -52,53 +72,207 @@ var ignoreTags = []string{"private", "PRIVATE", "-"}
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// Parse method parse given file and return document struct and slice with errors
-func Parse(file string) (*script.Document, errors.Errors) {
-	fd, err := os.OpenFile(file, os.O_RDONLY, 0)
+// Parse method parse given file and return document struct and slice with
+// diagnostics
+func Parse(file string, opts *Options) (*script.Document, Diagnostics) {
+	doc, bodies, links, diags := parseFile(file, opts)
+
+	if doc != nil {
+		linkBodies(doc, bodies)
+		diags = append(diags, checkLinks(doc, links)...)
+	}
+
+	return doc, diags
+}
+
+// FindMethods returns names of all methods (including undocumented and
+// private ones) defined in given script
+func FindMethods(file string) []string {
+	data, err := os.ReadFile(file)
 
 	if err != nil {
-		return nil, errors.Errors{fmt.Errorf("Can't open script file: %v", err)}
+		return nil
+	}
+
+	if isAutoloadFile(data) {
+		return []string{getAutoloadName(file)}
 	}
 
-	defer fd.Close()
+	var result []string
+
+	sh := detectShell(file, data)
+	lx := &lexer{}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if lx.InHeredoc() || lx.IsOpen() {
+			lx.Feed(line)
+			continue
+		}
+
+		line = strings.TrimLeft(line, " \t")
 
-	return readData(file, bufio.NewReader(fd))
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		lx.Feed(line)
+
+		t, name, _, _ := parseEntity(line, sh)
+
+		if t == ENT_TYPE_METHOD && !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// getDialect returns dialect set in options or nil if dialect must be
+// auto-detected
+func (o *Options) getDialect() Dialect {
+	if o == nil {
+		return nil
+	}
+
+	return o.Dialect
+}
+
+// parseFile parses script file
+func parseFile(file string, opts *Options) (*script.Document, []*methodBody, []*link, Diagnostics) {
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		return nil, nil, nil, Diagnostics{{
+			File:     file,
+			Severity: SEVERITY_ERROR,
+			Rule:     RULE_OPEN_ERROR,
+			Message:  fmt.Sprintf("Can't open script file: %v", err),
+		}}
+	}
+
+	return readScript(file, data, opts)
+}
+
 // readData reads file data
-func readData(file string, reader io.Reader) (*script.Document, errors.Errors) {
+func readData(file string, reader io.Reader) (*script.Document, Diagnostics) {
+	data, err := io.ReadAll(reader)
+
+	if err != nil {
+		return nil, Diagnostics{{
+			File:     file,
+			Severity: SEVERITY_ERROR,
+			Rule:     RULE_SCAN_ERROR,
+			Message:  fmt.Sprintf("Can't read script data: %v", err),
+		}}
+	}
+
+	doc, bodies, links, diags := readScript(file, data, nil)
+
+	linkBodies(doc, bodies)
+
+	return doc, append(diags, checkLinks(doc, links)...)
+}
+
+// readScript reads script data with detected dialect and shell, autoloaded
+// zsh functions are read as a single method
+func readScript(file string, data []byte, opts *Options) (*script.Document, []*methodBody, []*link, Diagnostics) {
+	if isAutoloadFile(data) {
+		return readAutoload(file, data, opts)
+	}
+
+	sh := detectShell(file, data)
+	d := opts.getDialect()
+
+	if d == nil {
+		d = detectDialect(data, sh)
+	}
+
+	return read(file, bytes.NewReader(data), d, sh)
+}
+
+// read reads file data with comments written in given dialect and returns
+// document, methods bodies, explicit references and diagnostics
+func read(file string, reader io.Reader, d Dialect, sh *shell) (*script.Document, []*methodBody, []*link, Diagnostics) {
 	scanner := bufio.NewScanner(reader)
 
 	var buffer []string
+	var bufferPos []LinePos
+	var diags Diagnostics
 	var methodsSection bool
 	var lineNum int
+	var body, anon *methodBody
+	var bodies []*methodBody
+	var section *script.Section
+	var links, aboutLinks []*link
 
-	doc := &script.Document{Title: filepath.Base(file)}
+	lx := &lexer{}
+	doc := &script.Document{Title: filepath.Base(file), File: file, Shell: sh.Name}
 
 	for scanner.Scan() {
 		line := scanner.Text()
-		line = strings.TrimLeft(line, " ")
 
 		lineNum++
 
+		if body != nil && body.IsClosed {
+			diags = append(diags, body.Check()...)
+			body = nil
+		}
+
+		if body != nil {
+			body.Add(line, !lx.InHeredoc() && !lx.IsOpen())
+		}
+
+		// Anonymous functions can't contain documented entities
+		if anon != nil {
+			anon.Add(line, !lx.InHeredoc() && !lx.IsOpen())
+			lx.Feed(line)
+
+			if anon.IsClosed {
+				anon = nil
+			}
+
+			continue
+		}
+
+		// Skip here-documents bodies and continuation of multiline strings
+		if lx.InHeredoc() || lx.IsOpen() {
+			lx.Feed(line)
+			continue
+		}
+
+		indent := len(line)
+		line = strings.TrimLeft(line, " ")
+		indent -= len(line)
+
 		if lineNum == 1 || shellcheckRegexp.MatchString(line) {
 			continue
 		}
 
 		if line == "" {
+			if isSectionMarker(buffer) {
+				var rest []string
+
+				section, rest, _ = readSection(doc, buffer, bufferPos)
+				links = append(links, findSectionLinks(file, buffer, bufferPos, rest)...)
+				buffer, bufferPos = nil, nil
+				continue
+			}
+
 			if buffer != nil && !doc.IsValid() {
-				doc.About = getCleanData(buffer)
+				doc.About = d.ParseAbout(buffer)
+				aboutLinks = findLinks(file, buffer, bufferPos)
 			}
 
-			buffer = nil
+			buffer, bufferPos = nil, nil
 			continue
 		}
 
 		if strings.Trim(line, "#") == "" {
 			if buffer != nil {
 				buffer = append(buffer, "")
+				bufferPos = append(bufferPos, LinePos{lineNum, indent + 2})
 			}
 
 			continue
@@ -106,115 +280,308 @@ func readData(file string, reader io.Reader) (*script.Document, errors.Errors) {
 
 		if line[0] == '#' {
 			buffer = append(buffer, line[2:])
+			bufferPos = append(bufferPos, LinePos{lineNum, indent + 3})
 			continue
 		}
 
-		t, name, value := parseEntity(line)
+		lx.Feed(line)
+
+		for _, path := range parseSources(line) {
+			doc.Includes = append(doc.Includes, &script.Include{
+				Path: path,
+				File: resolveSource(path, filepath.Dir(file)),
+				Line: lineNum,
+			})
+		}
+
+		if isSectionMarker(buffer) {
+			var rest []string
+			var restPos []LinePos
+
+			section, rest, restPos = readSection(doc, buffer, bufferPos)
+			links = append(links, findSectionLinks(file, buffer, bufferPos, rest)...)
+			buffer, bufferPos = rest, restPos
+		}
+
+		if sh.AnonRegExp != nil && sh.AnonRegExp.MatchString(line) {
+			anon = newMethodBody(line, lineNum, indent, sh)
+
+			if anon.IsClosed {
+				anon = nil
+			}
+
+			buffer, bufferPos = nil, nil
+			continue
+		}
+
+		// Comment before header commands (emulate -L zsh) contains info about script
+		if sh.HeaderRegExp != nil && sh.HeaderRegExp.MatchString(line) {
+			if buffer != nil && !doc.IsValid() {
+				doc.About = d.ParseAbout(buffer)
+				aboutLinks = findLinks(file, buffer, bufferPos)
+			}
+
+			buffer, bufferPos = nil, nil
+			continue
+		}
+
+		t, name, value, flags := parseEntity(line, sh)
+
+		if t == ENT_TYPE_METHOD {
+			diags = append(diags, body.Check()...)
+			body = newMethodBody(line, lineNum, indent, sh)
+			bodies = append(bodies, body)
+		}
+
+		if t == ENT_TYPE_METHOD && len(buffer) == 0 {
+			diags = append(diags, &Diagnostic{
+				Line:     lineNum,
+				Column:   indent + 1,
+				Severity: SEVERITY_NOTICE,
+				Rule:     RULE_MISSING_DOCS,
+				Message:  fmt.Sprintf("Method %s doesn't have documentation", name),
+				Entity:   name,
+			})
+		}
 
 		if t == ENT_TYPE_UNKNOWN || len(buffer) == 0 {
-			buffer = nil
+			buffer, bufferPos = nil, nil
 			continue
 		}
 
 		// Ignore all var definitions after first method
 		if t != ENT_TYPE_METHOD && methodsSection {
-			buffer = nil
+			buffer, bufferPos = nil, nil
 			continue
 		}
 
 		switch t {
 		case ENT_TYPE_METHOD:
-			m := parseMethodComment(name, buffer)
+			m := d.ParseMethod(name, buffer)
 
 			if m == nil {
-				buffer = nil
+				buffer, bufferPos = nil, nil
 				continue
 			}
 
-			m.Line = lineNum
+			m.Line, m.File = lineNum, file
 
 			// Methods MUST have description
-			if len(m.Desc) != 0 {
+			if hasDesc(m.Desc) {
+				if section != nil {
+					m.Section = section.Path
+					section.Methods = append(section.Methods, m)
+				}
+
 				doc.Methods = append(doc.Methods, m)
+				diags = append(diags, validateMethod(d, buffer, bufferPos)...)
+				links = append(links, findLinks(file, buffer, bufferPos)...)
+				body.Method, body.Comment, body.CommentPos = m, buffer, bufferPos
+			} else {
+				diags = append(diags, newMissingDescDiagnostic(name, bufferPos[0]))
 			}
 
 			if !methodsSection {
 				methodsSection = true
 			}
 
-			buffer = nil
+			buffer, bufferPos = nil, nil
 
 		case ENT_TYPE_VARIABLE, ENT_TYPE_CONSTANT:
-			v := parseVariableComment(name, value, buffer)
+			defLine := lineNum
+			value, valueLines := readValue(value, lx, scanner)
+
+			lineNum += valueLines
+
+			v := d.ParseVariable(name, value, buffer)
 
 			if v == nil {
-				buffer = nil
+				buffer, bufferPos = nil, nil
 				continue
 			}
 
-			// Append multiline parts to value
-			if isMultilineValue(value) {
-			MULTIPART:
-				for scanner.Scan() {
-					valuePart := scanner.Text()
+			applyDeclFlags(v, flags)
 
-					v.Value += valuePart
+			v.Line, v.File = defLine, file
 
-					if strutil.Tail(valuePart, 1) == "\"" {
-						break MULTIPART
-					}
+			if isArrayValue(value) {
+				v.Elements = parseArrayElements(value)
+
+				if v.IsAssoc && sh.HasPairs {
+					v.Elements = pairElements(v.Elements)
 				}
-			}
 
-			v.Line = lineNum
+				if v.IsUnknown() || v.IsString() {
+					v.Type = guessArrayType(v)
+				}
+			}
 
 			// Variables MUST have description
-			if len(v.Desc) != 0 {
+			if hasDesc(v.Desc) {
 				if t == ENT_TYPE_VARIABLE {
 					doc.Variables = append(doc.Variables, v)
 				} else {
 					doc.Constants = append(doc.Constants, v)
 				}
+
+				if section != nil {
+					v.Section = section.Path
+
+					if t == ENT_TYPE_VARIABLE {
+						section.Variables = append(section.Variables, v)
+					} else {
+						section.Constants = append(section.Constants, v)
+					}
+				}
+
+				diags = append(diags, validateVariable(d, buffer, bufferPos)...)
+				links = append(links, findLinks(file, buffer, bufferPos)...)
+			} else {
+				diags = append(diags, newMissingDescDiagnostic(name, bufferPos[0]))
 			}
 
-			buffer = nil
+			buffer, bufferPos = nil, nil
+		}
+	}
+
+	diags = append(diags, body.Check()...)
+
+	if err := scanner.Err(); err != nil {
+		diags = append(diags, &Diagnostic{
+			Line:     lineNum + 1,
+			Column:   1,
+			Severity: SEVERITY_ERROR,
+			Rule:     RULE_SCAN_ERROR,
+			Message:  fmt.Sprintf("Can't read script data: %v", err),
+		})
+	}
+
+	for _, d := range diags {
+		d.File = file
+	}
+
+	return doc, bodies, append(aboutLinks, links...), diags
+}
+
+// readValue reads assignment value which can span multiple lines and returns
+// value as written in the script and number of additional lines
+func readValue(value string, lx *lexer, scanner *bufio.Scanner) (string, int) {
+	vl := &lexer{}
+	end := vl.ScanValue(value, true)
+
+	if end != -1 {
+		return value[:end], 0
+	}
+
+	var lines int
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		lines++
+		lx.Feed(line)
+
+		end = vl.ScanValue(line, false)
+
+		if end != -1 {
+			return value + "\n" + line[:end], lines
 		}
+
+		value += "\n" + line
 	}
 
-	return doc, nil
+	return value, lines
 }
 
-// parseEntity method parse entity and return type, name and value of
-// entity
-func parseEntity(data string) (EntityType, string, string) {
-	if methodRegExp.MatchString(data) {
-		md := methodRegExp.FindStringSubmatch(data)
-		return ENT_TYPE_METHOD, md[1], ""
+// parseEntity method parse entity using rules of given shell and return type,
+// name, value and declaration flags of entity
+func parseEntity(data string, sh *shell) (EntityType, string, string, string) {
+	if sh.MethodRegExp.MatchString(data) {
+		md := sh.MethodRegExp.FindStringSubmatch(data)
+		return ENT_TYPE_METHOD, md[1], "", ""
+	}
+
+	// Definition with "function" keyword (function name, function name())
+	if sh.FuncRegExp.MatchString(data) {
+		md := sh.FuncRegExp.FindStringSubmatch(data)
+		return ENT_TYPE_METHOD, md[1], "", ""
+	}
+
+	if declRegExp.MatchString(data) {
+		return parseDeclaration(data, sh)
 	}
 
 	if variableRegExp.MatchString(data) {
 		vd := variableRegExp.FindStringSubmatch(data)
 
 		if constantRegExp.MatchString(vd[1]) {
-			return ENT_TYPE_CONSTANT, vd[1], vd[2]
+			return ENT_TYPE_CONSTANT, vd[1], vd[2], ""
+		}
+
+		return ENT_TYPE_VARIABLE, vd[1], vd[2], ""
+	}
+
+	return ENT_TYPE_UNKNOWN, "", "", ""
+}
+
+// parseDeclaration parses variable declaration with builtin (declare, typeset,
+// readonly, export, local, integer, float) and returns type, name, value and
+// flags of entity
+func parseDeclaration(data string, sh *shell) (EntityType, string, string, string) {
+	dd := declRegExp.FindStringSubmatch(data)
+	name, value := dd[3], dd[5]
+
+	var flags string
+
+	switch dd[1] {
+	case "readonly":
+		flags = "r"
+	case "export":
+		flags = "x"
+	case "integer":
+		flags = "i"
+	case "float":
+		flags = "F"
+	}
+
+	// Builtins integer and float are available only in zsh and ksh
+	if !sh.HasNumbers && (dd[1] == "integer" || dd[1] == "float") {
+		return ENT_TYPE_UNKNOWN, "", "", ""
+	}
+
+	for _, flag := range strings.Fields(dd[2]) {
+		// Flags with "+" prefix remove attributes
+		if flag[0] == '-' {
+			flags += flag[1:]
 		}
+	}
+
+	// declare -f/-F works with functions, not variables (typeset -F declares
+	// float in zsh and ksh)
+	if strings.Contains(flags, "f") || (strings.Contains(flags, "F") && !sh.HasNumbers) {
+		return ENT_TYPE_UNKNOWN, "", "", ""
+	}
 
-		return ENT_TYPE_VARIABLE, vd[1], vd[2]
+	if strings.Contains(flags, "r") || constantRegExp.MatchString(name) {
+		return ENT_TYPE_CONSTANT, name, value, flags
 	}
 
-	return ENT_TYPE_UNKNOWN, "", ""
+	return ENT_TYPE_VARIABLE, name, value, flags
 }
 
 // parseVariableComment method parse variable comment data and return
 // variable struct
 func parseVariableComment(name, value string, data []string) *script.Variable {
-	if len(data) == 0 || slices.Contains(ignoreTags, strings.TrimRight(data[0], " ")) {
+	if isIgnored(data) {
 		return nil
 	}
 
+	data, tags := parseTags(data)
+
 	variable := &script.Variable{
 		Name:  name,
 		Value: value,
+		Tags:  tags,
 	}
 
 	data, t := getVariableType(data)
@@ -232,19 +599,36 @@ func parseVariableComment(name, value string, data []string) *script.Variable {
 // parseMethodComment method parse method comment data and return
 // method struct
 func parseMethodComment(name string, data []string) *script.Method {
-	if len(data) == 0 || slices.Contains(ignoreTags, strings.TrimRight(data[0], " ")) {
+	if isIgnored(data) {
 		return nil
 	}
 
-	method := &script.Method{Name: name}
+	data, tags := parseTags(data)
+
+	method := &script.Method{Name: name, Tags: tags}
+
+	var skip int
 
 	for index, line := range data {
-		if methodArgRegExp.MatchString(line) {
+		// Skip lines of multi-line block
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		if isArgumentLine(line) {
 			if method.Desc == nil {
 				method.Desc = extractMethodDesc(data, index)
 			}
 
-			method.Arguments = append(method.Arguments, parseArgumentComment(line))
+			argument := parseArgumentComment(line)
+
+			var descData []string
+
+			descData, skip = getContinuationLines(data[index+1:])
+			appendArgumentDesc(argument, descData)
+
+			method.Arguments = append(method.Arguments, argument)
 
 			continue
 		}
@@ -260,7 +644,7 @@ func parseMethodComment(name string, data []string) *script.Method {
 				continue
 			}
 
-			method.ResultCode = true
+			method.ResultCodes, skip = parseCodes(retValue, data[index+1:])
 		}
 
 		if strings.HasPrefix(line, "Echo:") {
@@ -274,7 +658,63 @@ func parseMethodComment(name string, data []string) *script.Method {
 				continue
 			}
 
-			method.ResultEcho = parseVariableComment("", "", []string{echoValue})
+			var echoData []string
+
+			echoData, skip = getBlockLines(echoValue, data[index+1:])
+			method.ResultEcho = parseVariableComment("", "", echoData)
+		}
+
+		if strings.HasPrefix(line, "Stderr:") {
+			if method.Desc == nil {
+				method.Desc = extractMethodDesc(data, index)
+			}
+
+			stderrValue := strutil.Substr(line, 8, 99999)
+
+			if negativeValRegexp.MatchString(stderrValue) {
+				continue
+			}
+
+			method.Stderr, skip = getBlockLines(stderrValue, data[index+1:])
+		}
+
+		if strings.HasPrefix(line, "Files:") {
+			if method.Desc == nil {
+				method.Desc = extractMethodDesc(data, index)
+			}
+
+			filesValue := strutil.Substr(line, 7, 99999)
+
+			if negativeValRegexp.MatchString(filesValue) {
+				continue
+			}
+
+			var filesData []string
+
+			filesData, skip = getBlockLines(filesValue, data[index+1:])
+			method.Files = parseFiles(filesData)
+		}
+
+		if strings.HasPrefix(line, "Env:") || strings.HasPrefix(line, "Globals:") {
+			if method.Desc == nil {
+				method.Desc = extractMethodDesc(data, index)
+			}
+
+			record, refsValue, _ := strings.Cut(line, ":")
+
+			if negativeValRegexp.MatchString(strings.TrimSpace(refsValue)) {
+				continue
+			}
+
+			var refsData []string
+
+			refsData, skip = getBlockLines(refsValue, data[index+1:])
+
+			if record == "Env" {
+				method.Reads = append(method.Reads, parseVariableRefs(refsData)...)
+			} else {
+				method.Modifies = append(method.Modifies, parseVariableRefs(refsData)...)
+			}
 		}
 
 		if strings.HasPrefix(line, "Example:") {
@@ -303,33 +743,207 @@ func extractMethodDesc(data []string, index int) []string {
 	return getCleanData(data[:index])
 }
 
+// getBlockLines returns lines of multi-line block (value of the record and
+// following indented lines) and number of used lines
+func getBlockLines(value string, data []string) ([]string, int) {
+	var result []string
+
+	if strings.TrimSpace(value) != "" {
+		result = append(result, strings.TrimSpace(value))
+	}
+
+	lines, count := getContinuationLines(data)
+	result = append(result, lines...)
+
+	// Keep empty value for "Echo:" without data
+	if len(result) == 0 {
+		return []string{value}, count
+	}
+
+	return result, count
+}
+
+// getContinuationLines returns indented lines following the record and number
+// of used lines. Empty line between indented lines is kept as paragraph break.
+func getContinuationLines(data []string) ([]string, int) {
+	var result []string
+
+	for index, line := range data {
+		switch {
+		case isContinuationLine(line):
+			result = append(result, strings.TrimSpace(line))
+		case isParagraphBreak(data, index):
+			result = append(result, "")
+		default:
+			return result, index
+		}
+	}
+
+	return result, len(data)
+}
+
+// isContinuationLine returns true if given line is indented continuation of
+// the previous record
+func isContinuationLine(line string) bool {
+	if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+		return false
+	}
+
+	return strings.TrimSpace(line) != "" && !isArgumentLine(line)
+}
+
+// isParagraphBreak returns true if line with given index is an empty line
+// followed by continuation line
+func isParagraphBreak(data []string, index int) bool {
+	if strings.TrimSpace(data[index]) != "" || index+1 >= len(data) {
+		return false
+	}
+
+	return isContinuationLine(data[index+1])
+}
+
+// parseFiles parses info about files created or modified by method
+func parseFiles(data []string) []*script.File {
+	var result []*script.File
+
+	for _, line := range data {
+		if line == "" {
+			continue
+		}
+
+		path, desc, _ := strings.Cut(line, " - ")
+
+		result = append(result, &script.File{
+			Path: strings.TrimSpace(path),
+			Desc: strings.TrimSpace(desc),
+		})
+	}
+
+	return result
+}
+
+// parseVariableRefs parses info about variables used by method
+func parseVariableRefs(data []string) []*script.VariableRef {
+	var result []*script.VariableRef
+
+	for _, line := range data {
+		names, desc, _ := strings.Cut(line, " - ")
+
+		for _, name := range strings.Split(names, ",") {
+			name = strings.Trim(strings.TrimSpace(name), "${}")
+
+			if name != "" {
+				result = append(result, &script.VariableRef{Name: name, Desc: strings.TrimSpace(desc)})
+			}
+		}
+	}
+
+	return result
+}
+
+// parseCodes parses exit codes from value of "Code:" record and following
+// lines and returns codes and number of used lines
+func parseCodes(value string, data []string) (map[int]string, int) {
+	codes := map[int]string{}
+	last := parseCodesLine(value, codes)
+
+	var lines int
+
+LINES:
+	for index, line := range data {
+		switch {
+		case codeLineRegExp.MatchString(line):
+			last = parseCodesLine(line, codes)
+		case last == -1:
+			break LINES
+		case isContinuationLine(line):
+			codes[last] += "\n" + strings.TrimSpace(line)
+		case isParagraphBreak(data, index) && !codeLineRegExp.MatchString(data[index+1]):
+			codes[last] += "\n"
+		default:
+			break LINES
+		}
+
+		lines++
+	}
+
+	// Code: yes
+	if len(codes) == 0 {
+		return map[int]string{0: "ok", 1: "not ok"}, lines
+	}
+
+	return codes, lines
+}
+
+// parseCodesLine parses comma-separated list of exit codes and returns the
+// last parsed code or -1 if line doesn't contain codes
+func parseCodesLine(line string, codes map[int]string) int {
+	last := -1
+	locs := codeRegExp.FindAllStringSubmatchIndex(line, -1)
+
+	for i, loc := range locs {
+		end := len(line)
+
+		if i < len(locs)-1 {
+			end = locs[i+1][0]
+		}
+
+		last, _ = strconv.Atoi(line[loc[2]:loc[3]])
+		codes[last] = strings.TrimSpace(line[loc[1]:end])
+	}
+
+	return last
+}
+
+// isArgumentLine returns true if comment line contains argument info
+func isArgumentLine(line string) bool {
+	return getArgumentLoc(line) != nil
+}
+
+// getArgumentLoc returns positions of argument index, name (-1 if name is
+// not set) and description in comment line or nil if line doesn't contain
+// argument info
+func getArgumentLoc(line string) []int {
+	if loc := namedArgRegExp.FindStringSubmatchIndex(line); loc != nil {
+		return loc
+	}
+
+	loc := methodArgRegExp.FindStringSubmatchIndex(line)
+
+	if loc == nil {
+		return nil
+	}
+
+	return []int{loc[0], loc[1], loc[2], loc[3], -1, -1, loc[4], loc[5]}
+}
+
 // parseArgumentComment method parse given comment data and return
 // argument struct
 func parseArgumentComment(data string) *script.Argument {
 	argument := &script.Argument{}
 
-	ar := methodArgRegExp.FindStringSubmatch(data)
+	loc := getArgumentLoc(data)
 
-	argument.Index = ar[1]
+	parseArgumentIndex(argument, data[loc[2]:loc[3]])
+	descData := data[loc[6]:loc[7]]
 
-	if argument.Index == "*" {
-		argument.IsWildcard = true
+	switch {
+	case loc[4] != -1:
+		argument.Name = data[loc[4]:loc[5]]
+	case argNameRegExp.MatchString(descData):
+		argument.Name = argNameRegExp.FindStringSubmatch(descData)[1]
+		descData = descData[len(argNameRegExp.FindString(descData)):]
 	}
 
-	ds := strings.Split(ar[2], " ")
+	descData = parseArgumentConstraints(argument, descData)
+	ds := strings.Split(descData, " ")
 
 	var desc []string
 
 	for _, word := range ds {
-		switch word {
-		case "(Boolean)":
-			argument.Type = script.VAR_TYPE_BOOLEAN
-		case "(Number)":
-			argument.Type = script.VAR_TYPE_NUMBER
-		case "(String)":
-			argument.Type = script.VAR_TYPE_STRING
-		case "[Optional]":
-			argument.IsOptional = true
+		switch {
+		case argTypeRegExp.MatchString(word) && getTypeByName(strings.Trim(word, "()")) != script.VAR_TYPE_UNKNOWN:
+			argument.Type = getTypeByName(strings.Trim(word, "()"))
 		default:
 			desc = append(desc, word)
 		}
@@ -340,6 +954,128 @@ func parseArgumentComment(data string) *script.Argument {
 	return argument
 }
 
+// appendArgumentDesc appends continuation lines to argument description,
+// type marker at the end of the last line is also supported
+func appendArgumentDesc(argument *script.Argument, data []string) {
+	if len(data) == 0 {
+		return
+	}
+
+	last := strings.Split(data[len(data)-1], " ")
+	marker := last[len(last)-1]
+
+	if argument.Type == script.VAR_TYPE_UNKNOWN && argTypeRegExp.MatchString(marker) {
+		argument.Type = getTypeByName(strings.Trim(marker, "()"))
+
+		if argument.Type != script.VAR_TYPE_UNKNOWN {
+			data[len(data)-1] = strings.Join(last[:len(last)-1], " ")
+		}
+	}
+
+	argument.Desc = strings.Join(append([]string{argument.Desc}, data...), "\n")
+}
+
+// parseArgumentIndex parses argument index (1, 2..3, 2..*, 2+ or *) and sets
+// range of positions taken by argument
+func parseArgumentIndex(argument *script.Argument, index string) {
+	switch {
+	case index == "*":
+		argument.Index, argument.From, argument.To = index, 1, script.ARG_INDEX_ANY
+		return
+	case strings.HasSuffix(index, "+"):
+		index = strings.TrimSuffix(index, "+") + "..*"
+	}
+
+	argument.Index = index
+	from, to, isRange := strings.Cut(index, "..")
+
+	argument.From, _ = strconv.Atoi(from)
+
+	switch {
+	case !isRange:
+		argument.To = argument.From
+	case to == "*":
+		argument.To = script.ARG_INDEX_ANY
+	default:
+		argument.To, _ = strconv.Atoi(to)
+	}
+}
+
+// parseArgumentConstraints parses default value and constraints of argument
+// and returns description without them
+func parseArgumentConstraints(argument *script.Argument, data string) string {
+	var hasConstraints bool
+
+	for _, od := range argOptionalRegExp.FindAllStringSubmatch(data, -1) {
+		argument.IsOptional = true
+		argument.Default = strings.TrimSpace(od[1] + od[2])
+		hasConstraints = true
+	}
+
+	for _, rd := range argRangeRegExp.FindAllStringSubmatch(data, -1) {
+		argument.Type = getTypeByName(rd[1])
+
+		if rd[2] != "" {
+			min, _ := strconv.Atoi(rd[2])
+			argument.Min = &min
+		}
+
+		if rd[3] != "" {
+			max, _ := strconv.Atoi(rd[3])
+			argument.Max = &max
+		}
+
+		hasConstraints = true
+	}
+
+	for _, ed := range argEnumRegExp.FindAllStringSubmatch(data, -1) {
+		for _, value := range strings.Split(ed[1], "|") {
+			if strings.TrimSpace(value) != "" {
+				argument.Enum = append(argument.Enum, strings.TrimSpace(value))
+			}
+		}
+
+		if argument.Type == script.VAR_TYPE_UNKNOWN {
+			argument.Type = script.VAR_TYPE_STRING
+		}
+
+		hasConstraints = true
+	}
+
+	if !hasConstraints {
+		return data
+	}
+
+	for _, re := range []*regexp.Regexp{argOptionalRegExp, argRangeRegExp, argEnumRegExp} {
+		data = re.ReplaceAllString(data, "")
+	}
+
+	return strings.Join(strings.Fields(data), " ")
+}
+
+// applyDeclFlags applies declaration flags to variable attributes
+func applyDeclFlags(v *script.Variable, flags string) {
+	if flags == "" {
+		return
+	}
+
+	v.IsReadOnly = strings.Contains(flags, "r")
+	v.IsExported = strings.Contains(flags, "x")
+	v.IsInteger = strings.Contains(flags, "i")
+	v.IsIndexed = strings.Contains(flags, "a")
+	v.IsAssoc = strings.Contains(flags, "A")
+
+	// Attributes define type of variable
+	switch {
+	case v.IsAssoc:
+		v.Type = script.VAR_TYPE_MAP
+	case v.IsIndexed:
+		v.Type = script.VAR_TYPE_ARRAY
+	case v.IsInteger, strings.ContainsAny(flags, "EF"):
+		v.Type = script.VAR_TYPE_NUMBER
+	}
+}
+
 // guessVariableType try to guess variable type by value
 func guessVariableType(data string) script.VariableType {
 	if data == "" {
@@ -365,23 +1101,13 @@ func getVariableType(data []string) ([]string, script.VariableType) {
 
 	for _, line := range data {
 		if resultType == script.VAR_TYPE_UNKNOWN {
-			if typeCommentRegExp.MatchString(line) {
-				cd := typeCommentRegExp.FindStringSubmatch(line)
+			cd := typeCommentRegExp.FindStringSubmatch(line)
 
+			if cd != nil && getTypeByName(cd[2]) != script.VAR_TYPE_UNKNOWN {
 				// Append to result first regexp group contains
 				// description without type marker
 				result = append(result, cd[1])
-
-				switch cd[2] {
-				case "Boolean":
-					resultType = script.VAR_TYPE_BOOLEAN
-
-				case "Number":
-					resultType = script.VAR_TYPE_NUMBER
-
-				default:
-					resultType = script.VAR_TYPE_STRING
-				}
+				resultType = getTypeByName(cd[2])
 
 				continue
 			}
@@ -393,6 +1119,29 @@ func getVariableType(data []string) ([]string, script.VariableType) {
 	return getCleanData(result), resultType
 }
 
+// getTypeByName returns type with given name
+func getTypeByName(name string) script.VariableType {
+	return script.FindType(name)
+}
+
+// isTypeComment returns true if comment line contains known type marker
+func isTypeComment(line string) bool {
+	cd := typeCommentRegExp.FindStringSubmatch(line)
+	return cd != nil && getTypeByName(cd[2]) != script.VAR_TYPE_UNKNOWN
+}
+
+// hasDesc returns true if description contains something except type marker
+func hasDesc(desc []string) bool {
+	switch len(desc) {
+	case 0:
+		return false
+	case 1:
+		return !argTypeRegExp.MatchString(strings.TrimSpace(desc[0]))
+	}
+
+	return true
+}
+
 // getCleanData return removes empty lines and whitespaces at the
 // end of the line
 func getCleanData(data []string) []string {
@@ -420,11 +1169,107 @@ func getCleanData(data []string) []string {
 	return result
 }
 
-// isMultilineValue return true if value is multiline string
-func isMultilineValue(value string) bool {
-	if strutil.Head(value, 1) == "\"" && strutil.Tail(value, 1) != "\"" {
-		return true
+// isArrayValue return true if value is array definition
+func isArrayValue(value string) bool {
+	return strutil.Head(value, 1) == "("
+}
+
+// parseArrayElements parses array definition and returns slice with elements
+func parseArrayElements(data string) []*script.Element {
+	words := scanArray(data)
+
+	var result []*script.Element
+
+	for _, word := range words {
+		if arrayKeyRegExp.MatchString(word) {
+			kd := arrayKeyRegExp.FindStringSubmatch(word)
+			result = append(result, &script.Element{Key: kd[1], Value: kd[2]})
+		} else {
+			result = append(result, &script.Element{Value: word})
+		}
+	}
+
+	return result
+}
+
+// scanArray scans array definition and returns all top-level words
+func scanArray(data string) []string {
+	var words []string
+	var word strings.Builder
+	var quote rune
+	var depth int
+	var escaped, comment bool
+
+	flushWord := func() {
+		if word.Len() != 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+
+	for _, r := range data {
+		switch {
+		case comment:
+			if r == '\n' {
+				comment = false
+			}
+			continue
+
+		case escaped:
+			escaped = false
+
+		case r == '\\' && quote != '\'':
+			escaped = true
+
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+
+		case r == '"' || r == '\'':
+			quote = r
+
+		case r == '#' && word.Len() == 0 && depth == 1:
+			comment = true
+			continue
+
+		case r == '(':
+			depth++
+
+			if depth == 1 {
+				continue
+			}
+
+		case r == ')':
+			depth--
+
+			if depth == 0 {
+				flushWord()
+				return words
+			}
+
+		case depth == 1 && (r == ' ' || r == '\t' || r == '\n'):
+			flushWord()
+			continue
+		}
+
+		if depth > 0 {
+			word.WriteRune(r)
+		}
+	}
+
+	flushWord()
+
+	return words
+}
+
+// guessArrayType returns array type based on array elements
+func guessArrayType(v *script.Variable) script.VariableType {
+	for _, e := range v.Elements {
+		if e.Key != "" && !numberRegExp.MatchString(e.Key) {
+			return script.VAR_TYPE_MAP
+		}
 	}
 
-	return false
+	return script.VAR_TYPE_ARRAY
 }
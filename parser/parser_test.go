@@ -8,7 +8,10 @@ package parser
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/essentialkaos/shdoc/script"
@@ -197,6 +200,942 @@ method13(){
 }
 `
 
+const _SCRIPT_FUNCS = `#!/bin/bash
+
+# Method defined with function keyword
+function method1 {
+  stub=1
+}
+
+# Method defined with function keyword and parentheses
+function method2() {
+  stub=1
+}
+
+# Method defined with space before parentheses
+method3 () {
+  stub=1
+}
+
+# Method defined with brace on next line
+function method4
+{
+  stub=1
+}
+
+# Method defined with parentheses and brace on next line
+method5()
+{
+  stub=1
+}
+
+# Method defined with function keyword, spaces and comment
+function  method6 ( ) # comment
+{
+  stub=1
+}
+
+# Not a method
+functions=1
+`
+
+const _SCRIPT_DECLS = `#!/bin/bash
+
+# Read-only constant
+readonly MAX_RETRIES=5
+
+# Read-only lowercase constant
+declare -r max_delay=10
+
+# Exported variable
+export path_prefix="/usr"
+
+# Integer variable
+declare -i count=0
+
+# Exported integer with multiple flags
+typeset -x -i total
+
+# Array variable
+declare -a hosts
+
+# Associative array
+declare -A MAP
+
+# Function declaration is not a variable
+declare -f my_func
+
+# Exported and then unexported variable
+declare +x -r mode="fast"
+`
+
+const _SCRIPT_ARRAYS = `#!/bin/bash
+
+# Simple array
+HOSTS=(a b c)
+
+# Multiline array with comments and quotes
+PORTS=(
+  80   # HTTP
+  "443"
+  'some value'
+)
+
+# Associative array
+declare -A MAP=([key1]=value1 [key2]="value 2")
+
+# Associative array with multiline definition (Map)
+OPTS=(
+  [verbose]=true
+  [level]=$(get_level "a b")
+)
+
+# Array with explicit indexes
+IDX=([0]=zero [3]=three)
+
+# Variable after arrays
+VAR_AFTER=1
+`
+
+const _SCRIPT_HEREDOCS = `#!/bin/bash
+
+# Method with heredoc
+method1() {
+  cat <<EOF
+# This is not a comment
+fake1() {
+EOF
+}
+
+# Method with indented heredoc with quoted delimiter
+method2() {
+	cat <<-'END'
+	# This is not a comment
+	fake2() {
+	END
+}
+
+# Method with heredoc with double-quoted delimiter inside command substitution
+method3() {
+  local data=$(cat <<"DATA"
+# This is not a comment
+fake3() {
+DATA
+)
+}
+
+# Method with multiline string
+method4() {
+  echo "Usage:
+# This is not a comment
+fake4() {
+"
+}
+
+# Method with here-string and arithmetic shift
+method5() {
+  cat <<< "text"
+  local x=$(( 1 << 2 ))
+}
+
+# Method with escaped quote
+method6() {
+  echo "\" # not closed"
+}
+
+# Method after all
+method7() {
+  :
+}
+`
+
+const _SCRIPT_VALUES = `#!/bin/bash
+
+# Value with comment
+VAL_1="value" # comment
+
+# Number with comment
+VAL_2=10 # comment
+
+# Multiline value in single quotes
+VAL_3='first "line"
+second line'
+
+# Value with escaped quotes
+VAL_4="some \"quoted\"
+text"
+
+# Command substitution
+VAL_5=$(echo "a" |
+  tr 'a' 'b')
+
+# Backslash continuation
+VAL_6=abc\
+def
+
+# Value with hash
+VAL_7=#abc
+
+# Value with parameter expansion
+VAL_8="${HOME:-"/root"}/dir" ; echo 1
+
+# Variable after all
+VAL_9=1
+`
+
+const _SCRIPT_DIAGS = `#!/bin/bash
+
+# Constant with unknown type (Strng)
+CONST_1=1
+
+# Method with problems
+#
+# 1: First argument (Strng)
+# 1: Duplicate argument
+# 2: (String)
+#
+# Example:
+#
+  method1() {
+  stub=1
+}
+
+helper() {
+  stub=1
+}
+`
+
+const _SCRIPT_PARAMS = `#!/bin/bash
+
+# Method with shifts
+#
+# 1: Name (String)
+# 2: Value (String)
+# 3: Unused argument
+#
+method1() {
+  local name="$1"
+  shift
+  local value="${1:-default}"
+  awk '{print $5}' <<< "$name $value"
+  echo "${4}" # $6
+}
+
+# Method with wildcard
+#
+# 1: Name
+#
+method2() {
+  printf '%s\n' "$@"
+}
+
+# One-line method
+#
+# 1: Name
+#
+method3() { echo "${#1}"; }
+
+# Method with loop
+#
+# 1: Option
+# 2: Option value
+#
+method4() {
+  while [[ -n "$1" ]] ; do
+    shift
+  done
+}
+
+# Method with documented wildcard
+#
+# 1: Name
+# *: Other arguments
+#
+method5() {
+  echo "$1 $2 $3"
+}
+
+# Method with ranged arguments
+#
+# 1..2: Key and value
+# 3..*: Options
+#
+method6() {
+  echo "ok"
+}
+`
+
+const _SCRIPT_PARAMS_ANNOTATED = `#!/bin/bash
+
+# @description Connect to server
+# @arg $1 string Host
+# @arg $2 int Port
+connect() {
+  nc "$1" 80
+}
+`
+
+const _SCRIPT_DIALECT_STRAY = `#!/bin/bash
+
+# Path to config (String)
+CONFIG="/etc/app.conf"
+
+# Read config option
+#
+# 1: Name of option (String)
+#
+# Echo: Option value (String)
+getOption() {
+  # Returns: value of option
+  result=$(grep "^$1:" "$CONFIG")
+  echo "${result#*:}"
+}
+`
+
+const _SCRIPT_PARAMS_HEREDOC = `#!/bin/bash
+
+# Print greeting
+#
+# 1: Name
+# 2: Greeting
+greet() {
+  cat <<EOF
+$2, ${1}!
+EOF
+}
+
+# Print template
+#
+# 1: Name
+template() {
+  cat <<-'EOF'
+	Hello, $1 and $2
+	EOF
+  echo "$1"
+}
+`
+
+const _SCRIPT_CALLS = `#!/bin/bash
+
+# Main entry
+#
+# *: Arguments
+main() {
+  local result=$(getValue "$@")
+  if checkValue "$result" ; then
+    printValue "$result" # printValue
+  fi
+
+  helper
+}
+
+# Get value
+#
+# *: Arguments
+getValue() { echo "$*" | tr a-z A-Z; }
+
+# Count down
+#
+# 1: Counter
+countDown() {
+  if [[ $1 -gt 0 ]] ; then
+    countDown $(( $1 - 1 ))
+  fi
+}
+
+# Check value
+#
+# 1: Value
+checkValue() {
+  [[ -n "$1" ]] && printValue "ok"
+}
+
+# Print value
+#
+# 1: Value
+printValue() {
+  cat <<EOF
+checkValue $1
+EOF
+}
+
+helper() {
+  main
+}
+`
+
+const _SCRIPT_SET_MAIN = `#!/bin/bash
+
+source "$(dirname "$0")/lib/net.sh"
+[[ -f lib/missing.sh ]] && . lib/missing.sh
+source "$LIB_DIR/x.sh" # dynamic path
+
+# Main entry, see {@link download}
+#
+# *: Arguments
+main() {
+  download "$@"
+}
+`
+
+const _SCRIPT_SET_LIB = `#!/bin/bash
+
+source ${BASH_SOURCE%/*}/../main.sh
+
+# Download file
+#
+# 1: URL
+download() {
+  curl -sL "$1"
+}
+`
+
+const _SCRIPT_TAGS = `#!/bin/bash
+
+# Path to temporary directory (String)
+# @deprecated Use TMPDIR variable
+# @since 1.2.0
+TEMP_DIR="/tmp"
+
+# Download file
+#
+# @deprecated fetch()
+# @since 1.0.0
+# @see fetch
+# @see https://curl.se
+# @author John Doe
+# @author Jane Doe
+# @version 2
+#
+# 1: URL
+#
+# Example:
+#   @download https://domain.com/file.txt
+download() {
+  fetch "$1"
+}
+
+# Fetch file
+#
+# @since 2.0.0
+#
+# 1: URL
+fetch() {
+  curl -sL "$1"
+}
+`
+
+const _SCRIPT_CODES = `#!/bin/bash
+
+# Download file
+#
+# 1: URL
+#
+# Code: 0 - ok, 1 - download error, 2 - bad arguments
+download() {
+  curl -sL "$1"
+}
+
+# Upload file
+#
+# 1: URL
+#
+# Code:
+#   0 - ok
+#   2 - bad arguments, 3 - network error
+#   10 - server error - try again later
+# Echo: Server response (String)
+upload() {
+  curl -sL -T - "$1"
+}
+
+# Check connection
+#
+# Code: Yes
+check() {
+  ping -c 1 kaos.sh
+}
+`
+
+const _SCRIPT_CODES_RANGE = `#!/bin/bash
+
+# Download file
+#
+# Code: 0 - ok, 300 - download error
+#   256 - bad arguments
+download() {
+  curl -sL kaos.sh
+}
+`
+
+const _SCRIPT_CODES_ANNOTATED = `#!/bin/bash
+
+# @description Upload file
+# @exitcode 0 ok
+# @exitcode 1024 network error
+upload() {
+  curl -sL -T - kaos.sh
+}
+`
+
+const _SCRIPT_OUTPUT = `#!/bin/bash
+
+# List hosts
+#
+# 1: Path to inventory (String)
+#
+# Echo: Hosts list (String)
+#   one host per line, tab-separated
+#   name, address and port
+# Stderr: Error message if inventory
+#   can't be read
+# Files:
+#   /tmp/hosts.cache - Cache with hosts
+#   ~/.hosts_history
+#
+# Example:
+#   listHosts inventory.ini
+listHosts() {
+  cat "$1" | tee /tmp/hosts.cache
+}
+
+# Save hosts
+#
+# Echo: No
+# Stderr: No
+# Files: /etc/hosts - System hosts file
+saveHosts() {
+  cat > /etc/hosts
+}
+`
+
+const _SCRIPT_VARS = `#!/bin/bash
+
+# Max number of retries (Number)
+MAX_RETRIES=3
+
+# Last error message (String)
+LAST_ERROR=""
+
+# Number of requests (Number)
+REQUESTS=0
+
+# Download file
+#
+# 1: URL
+#
+# Env: HTTP_PROXY - Proxy URL
+#   $NO_PROXY, ${CURL_HOME}
+# Globals: LAST_ERROR - Error message
+download() {
+  local retries=0 REQUESTS
+
+  while [[ $retries -lt ${MAX_RETRIES} ]] ; do
+    ((REQUESTS++))
+    curl -sL "$1" && return 0
+    LAST_ERROR="Can't download $1"
+  done
+
+  return 1
+}
+
+# Reset state
+#
+# Env: No
+# Globals: No
+reset() { LAST_ERROR=""; REQUESTS=0; unset MAX_RETRIES; }
+
+# Print state
+#
+# Example:
+#   printState
+printState() {
+  cat <<EOF
+Requests: $REQUESTS
+EOF
+  cat <<'EOF'
+Error: $LAST_ERROR
+EOF
+}
+`
+
+const _SCRIPT_STATE = `#!/bin/bash
+
+# Current state (String)
+STATE=""
+
+# Number of retries (Number)
+RETRIES=0
+
+# Update state
+#
+# 1: New state
+update() {
+  declare RETRIES=1
+  echo RETRIES=2
+  printf '%s' STATE=$RETRIES
+
+  [[ -n "$1" ]] && STATE="$1"
+}
+`
+
+const _SCRIPT_NAMES = `#!/bin/bash
+
+# Connect to host
+#
+# 1 host: Target host (String)
+# 2: <port> Target port (Number) [Optional]
+# 3: User name (String) [Optional]
+# 4: Timeout (Number) [Optional]
+# * opts: SSH options
+connect() {
+  local host="$1"
+  local -r user="${3:-root}" ; local timeout=$4
+  local port
+  port="$2"
+  shift 4
+  ssh "$@"
+}
+
+# Print message
+#
+# 1: Message (String)
+# 2: Color (String)
+print() {
+  local msg=$1
+  shift
+  local color="$1"
+  echo "$color$msg"
+}
+`
+
+const _SCRIPT_CONSTRAINTS = `#!/bin/bash
+
+# Start service
+#
+# 1 action: Action (Enum: start|stop|restart) [Default: start]
+# 2 port: Port (Number 1..65535) [Optional=8080]
+# 3 retries: Number of retries (Number 0..) [Optional]
+# 4 mode: Mode (Enum: fast|slow) [Default: medium]
+# 5 timeout: Timeout (Number 10..1) [Optional=abc]
+# 6 level: Level (Level 1..3)
+service() {
+  echo "$1 $2 $3 $4 $5 $6"
+}
+`
+
+const _SCRIPT_TYPES = `#!/bin/bash
+
+# Path to configuration file (Path)
+CONFIG="/etc/app.conf"
+
+# Repository URL (URL)
+REPO_URL="https://example.com/repo.git"
+
+# Copy file to remote host
+#
+# 1: Source file (File)
+# 2: Target directory (Dir)
+# 3: Remote host (Host)
+# 4: Connection speed (Speed)
+upload() {
+  echo "$1 $2 $3 $4"
+}
+`
+
+const _SCRIPT_RANGES = `#!/bin/bash
+
+# Join items with separator
+#
+# 1 sep: Separator (String)
+# 2..* items: Items to join
+join_by() {
+  local sep="$1"
+  shift
+  echo "$*"
+}
+
+# Set options
+#
+# 1: Config file (File)
+# 2..3 option: Option name and value
+# 4+: Other options
+set_options() {
+  echo "$1 $2 $3 $4 $5 $6 $7"
+}
+
+# Print pairs
+#
+# 3..2: Broken range
+# 0..*: Broken index
+print_pairs() {
+  echo "$1 $2 $3 $4"
+}
+`
+
+const _SCRIPT_MULTILINE = `#!/bin/bash
+
+# Sync directories
+#
+# 1: Source directory, all files from this
+#    directory will be copied
+#
+#    Symlinks are ignored (Dir)
+# 2: Target directory (Dir)
+#
+# Code:
+#   0 - synced
+#   1 - source directory
+#       doesn't exist
+#
+#       Check the path
+#   2 - failed
+# Echo: List of copied files
+#
+#   One file per line (String)
+sync_dirs() {
+  rsync "$1" "$2"
+}
+`
+
+const _SCRIPT_SECTIONS = `#!/bin/bash
+
+# Library with helpers
+
+# @section Networking
+# Network helpers
+
+# Default port (Number)
+PORT=80
+
+# Connect to host
+#
+# 1: Host (String)
+connect() {
+  echo "$1"
+}
+
+# @section Networking/HTTP
+#
+# Fetch URL
+#
+# 1: URL (URL)
+fetch() {
+  curl "$1"
+}
+
+# @section Strings
+
+# Trim string
+#
+# 1: String (String)
+trim() {
+  echo "$1"
+}
+
+# @section Networking
+
+# Disconnect from host
+disconnect() {
+  echo "bye"
+}
+`
+const _SCRIPT_LINKS = `#!/bin/bash
+
+# Library for {@link $BASE_DIR} and {@link unknown}
+
+# @section Files
+# Helpers for {@link copy_file()} and {@link missing_section}
+
+# Base directory, see ` + "`copy_file`" + ` (String)
+BASE_DIR="/srv"
+
+# Copy file to {@link BASE_DIR}, see {@link ${TARGET}}
+#
+# 1: Source (String)
+#
+# Example:
+#   {@link not_checked}
+copy_file() {
+  cp "$1" "$BASE_DIR"
+}
+`
+const _SCRIPT_ANNOTATED = `#!/bin/bash
+
+# @file ann.sh
+# @brief Library with helpers
+# @description Helpers for greeting people.
+
+# @description Default name
+#   used for greetings (String)
+NAME="World"
+
+# @description Says hello to someone.
+#   Second line of description.
+#
+# @arg $1 string Name of person
+# @arg $2 int Number of greetings [Default: 1]
+# @arg $@ any Extra words
+#
+# @set GREETED string Name of greeted person
+#
+# @exitcode 0 If successful.
+# @exitcode 1 If an empty string passed.
+#   Also if count is invalid.
+#
+# @stdout Greeting text.
+# @stderr Errors.
+#
+# @example
+#   say_hello "John" 2
+#     echo indented
+#
+# @see NAME
+# @deprecated say_hi
+say_hello() {
+  echo "Hello $1 $2 $*"
+}
+
+# @description Internal helper
+# @internal
+_helper() {
+  :
+}
+
+# @description Bad one
+# @arg name Something
+# @unknwn tag
+bad_one() {
+  echo "$1"
+}
+`
+
+const _SCRIPT_GOOGLE = `#!/bin/bash
+#
+# Perform hot backups of Oracle databases.
+
+# Backup directory (String)
+BACKUP_DIR="/backup"
+
+#######################################
+# Cleanup files from the backup directory.
+# Globals:
+#   BACKUP_DIR
+#   ORACLE_SID - SID of database
+# Arguments:
+#   $1 - Directory to clean
+#     with continuation
+#   Pattern of files to remove
+# Outputs:
+#   Writes location to stdout
+#   Writes errors to stderr
+# Returns:
+#   0 if thing was deleted, non-zero on error.
+#######################################
+cleanup() {
+  rm "${BACKUP_DIR}/$1/$2"
+}
+
+#######################################
+# Get configuration directory.
+# Globals:
+#   None
+# Arguments:
+#   None
+# Outputs:
+#   Writes location to stdout
+#######################################
+get_dir() {
+  echo "${XDG_CONFIG_HOME:-${HOME}/.config}"
+}
+`
+
+const _SCRIPT_GOOGLE_DIAGS = `#!/bin/bash
+
+#######################################
+# Copy file.
+# Arguments:
+#   $1 - Source file (Strng)
+#   $1 - Duplicate source
+#   $0 - Script name
+#   $2 -
+# Returns:
+#   0 on success
+#   1000 weird
+#######################################
+copy_file() {
+  cp "$1" "$2"
+}
+`
+
+const _SCRIPT_ZSH = `#!/usr/bin/env zsh
+# Plugin with helpers for git repositories
+emulate -L zsh
+setopt extendedglob
+
+# Map with remote aliases
+typeset -gA REMOTES=(origin github upstream gitlab)
+
+# Precision of numbers
+float PRECISION=0.5
+
+# Max depth of search
+integer MAX_DEPTH=3
+
+() {
+  # Local helper variable
+  local tmp=1
+}
+
+# Shows status of repository
+#
+# 1: Path to repository (String)
+git:status() {
+  git -C "$argv[1]" status
+  print "${argv[2]}"
+}
+
+# Prints prompt segment
+function +vi-segment {
+  () { print 1 }
+  git:status "$PWD"
+}
+`
+
+const _SCRIPT_KSH = `#!/bin/mksh
+# Tools for ksh
+
+# Ratio of something
+typeset -F RATIO=1.5
+
+# Prints ratio
+#
+# 1: Prefix
+function print_ratio {
+  print "$1 $RATIO"
+}
+`
+
+const _AUTOLOAD_GREET = `#autoload
+emulate -L zsh
+
+# Greets user
+#
+# 1: Name of user (String)
+print "Hello $1"
+`
+
+const _AUTOLOAD_JOIN = `# Joins arguments with separator
+#
+# 1: Separator
+# 2..*: Elements
+
+local sep=$1
+shift
+greet "$sep"
+print "$@"
+`
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 func Test(t *testing.T) { TestingT(t) }
@@ -213,28 +1152,80 @@ var _ = Suite(&ParseSuite{})
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-func (s *ParseSuite) SetUpSuite(c *C) {
-	s.TmpDir = c.MkDir()
+func (s *ParseSuite) SetUpSuite(c *C) {
+	s.TmpDir = c.MkDir()
+
+	err := os.WriteFile(s.TmpDir+"/script.sh", []byte(_SCRIPT), 0644)
+
+	if err != nil {
+		c.Fatal(err.Error())
+	}
+
+	err = os.MkdirAll(s.TmpDir+"/set/lib", 0755)
+
+	if err != nil {
+		c.Fatal(err.Error())
+	}
+
+	err = os.WriteFile(s.TmpDir+"/set/main.sh", []byte(_SCRIPT_SET_MAIN), 0644)
+
+	if err != nil {
+		c.Fatal(err.Error())
+	}
+
+	err = os.WriteFile(s.TmpDir+"/set/lib/net.sh", []byte(_SCRIPT_SET_LIB), 0644)
+
+	if err != nil {
+		c.Fatal(err.Error())
+	}
+
+	functions := map[string]string{
+		"greet":        _AUTOLOAD_GREET,
+		"join.zsh":     _AUTOLOAD_JOIN,
+		"undocumented": "print 1",
+		"README.md":    "# Functions",
+		".hidden":      "# Hidden\nprint 1",
+	}
 
-	err := os.WriteFile(s.TmpDir+"/script.sh", []byte(_SCRIPT), 0644)
+	err = os.MkdirAll(s.TmpDir+"/functions", 0755)
 
 	if err != nil {
 		c.Fatal(err.Error())
 	}
+
+	for name, data := range functions {
+		err = os.WriteFile(s.TmpDir+"/functions/"+name, []byte(data), 0644)
+
+		if err != nil {
+			c.Fatal(err.Error())
+		}
+	}
 }
 
 func (s *ParseSuite) TestErrors(c *C) {
-	doc, errs := Parse(s.TmpDir + "/script1.sh")
+	doc, errs := Parse(s.TmpDir+"/script1.sh", nil)
 
 	c.Assert(doc, IsNil)
 	c.Assert(errs, Not(HasLen), 0)
+	c.Assert(errs[0].Rule, Equals, RULE_OPEN_ERROR)
+	c.Assert(errs.HasErrors(), Equals, true)
 }
 
 func (s *ParseSuite) TestParsing(c *C) {
-	doc, errs := Parse(s.TmpDir + "/script.sh")
+	doc, errs := Parse(s.TmpDir+"/script.sh", nil)
 
 	c.Assert(doc, NotNil)
-	c.Assert(errs, HasLen, 0)
+
+	// Usage of arguments is checked by TestArgumentsUsage
+	errs = skipRules(errs, RULE_UNUSED_ARGUMENT)
+
+	c.Assert(errs, HasLen, 3)
+
+	for i, entity := range []string{"method10", "method11", "method13"} {
+		c.Assert(errs[i].Rule, Equals, RULE_MISSING_DESC)
+		c.Assert(errs[i].Entity, Equals, entity)
+		c.Assert(errs[i].Severity, Equals, SEVERITY_WARNING)
+	}
 
 	c.Assert(doc.IsValid(), Equals, true)
 	c.Assert(doc.HasAbout(), Equals, true)
@@ -418,7 +1409,7 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Variables[7].Name, Equals, "var_8")
 	c.Assert(doc.Variables[7].Desc, DeepEquals, []string{"Variable #8 with multiline value"})
 	c.Assert(doc.Variables[7].Type, Equals, script.VariableType(script.VAR_TYPE_STRING))
-	c.Assert(doc.Variables[7].Value, Equals, "\"This is multiline value\"")
+	c.Assert(doc.Variables[7].Value, Equals, "\"This is \nmultiline \nvalue\"")
 	c.Assert(doc.Variables[7].Line, Equals, 62)
 	c.Assert(doc.Variables[7].IsString(), Equals, true)
 	c.Assert(doc.Variables[7].IsNumber(), Equals, false)
@@ -442,10 +1433,10 @@ func (s *ParseSuite) TestParsing(c *C) {
 		"Fourth line of description.",
 	})
 	c.Assert(doc.Methods[0].Arguments, HasLen, 0)
-	c.Assert(doc.Methods[0].ResultCode, Equals, false)
+	c.Assert(doc.Methods[0].ResultCodes, IsNil)
 	c.Assert(doc.Methods[0].ResultEcho, IsNil)
 	c.Assert(doc.Methods[0].Example, HasLen, 0)
-	c.Assert(doc.Methods[0].Line, Equals, 78)
+	c.Assert(doc.Methods[0].Line, Equals, 80)
 	c.Assert(doc.Methods[0].HasArguments(), Equals, false)
 	c.Assert(doc.Methods[0].HasEcho(), Equals, false)
 	c.Assert(doc.Methods[0].HasEcho(), Equals, false)
@@ -461,7 +1452,7 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[1].Arguments[0].Desc, Equals, "First argument")
 	c.Assert(doc.Methods[1].Arguments[0].Type, Equals, script.VariableType(script.VAR_TYPE_STRING))
 	c.Assert(doc.Methods[1].Arguments[0].IsOptional, Equals, false)
-	c.Assert(doc.Methods[1].Arguments[0].IsWildcard, Equals, false)
+	c.Assert(doc.Methods[1].Arguments[0].IsVariadic(), Equals, false)
 	c.Assert(doc.Methods[1].Arguments[0].TypeName(0), Equals, "String")
 	c.Assert(doc.Methods[1].Arguments[0].TypeName(1), Equals, "string")
 	c.Assert(doc.Methods[1].Arguments[0].TypeName(2), Equals, "STRING")
@@ -477,7 +1468,7 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[1].Arguments[1].Desc, Equals, "Second argument")
 	c.Assert(doc.Methods[1].Arguments[1].Type, Equals, script.VariableType(script.VAR_TYPE_NUMBER))
 	c.Assert(doc.Methods[1].Arguments[1].IsOptional, Equals, false)
-	c.Assert(doc.Methods[1].Arguments[1].IsWildcard, Equals, false)
+	c.Assert(doc.Methods[1].Arguments[1].IsVariadic(), Equals, false)
 	c.Assert(doc.Methods[1].Arguments[1].TypeName(0), Equals, "Number")
 	c.Assert(doc.Methods[1].Arguments[1].TypeName(1), Equals, "number")
 	c.Assert(doc.Methods[1].Arguments[1].TypeName(2), Equals, "NUMBER")
@@ -493,7 +1484,7 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[1].Arguments[2].Desc, Equals, "Third argument")
 	c.Assert(doc.Methods[1].Arguments[2].Type, Equals, script.VariableType(script.VAR_TYPE_BOOLEAN))
 	c.Assert(doc.Methods[1].Arguments[2].IsOptional, Equals, false)
-	c.Assert(doc.Methods[1].Arguments[2].IsWildcard, Equals, false)
+	c.Assert(doc.Methods[1].Arguments[2].IsVariadic(), Equals, false)
 	c.Assert(doc.Methods[1].Arguments[2].TypeName(0), Equals, "Boolean")
 	c.Assert(doc.Methods[1].Arguments[2].TypeName(1), Equals, "boolean")
 	c.Assert(doc.Methods[1].Arguments[2].TypeName(2), Equals, "BOOLEAN")
@@ -509,7 +1500,7 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[1].Arguments[3].Desc, Equals, "Wildcard argument")
 	c.Assert(doc.Methods[1].Arguments[3].Type, Equals, script.VariableType(script.VAR_TYPE_UNKNOWN))
 	c.Assert(doc.Methods[1].Arguments[3].IsOptional, Equals, false)
-	c.Assert(doc.Methods[1].Arguments[3].IsWildcard, Equals, true)
+	c.Assert(doc.Methods[1].Arguments[3].IsVariadic(), Equals, true)
 	c.Assert(doc.Methods[1].Arguments[3].TypeName(0), Equals, "")
 	c.Assert(doc.Methods[1].Arguments[3].TypeName(1), Equals, "")
 	c.Assert(doc.Methods[1].Arguments[3].TypeName(2), Equals, "")
@@ -520,7 +1511,7 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[1].Arguments[3].IsBoolean(), Equals, false)
 	c.Assert(doc.Methods[1].Arguments[3].IsUnknown(), Equals, true)
 	// //////////////////////////////////////////////////////////////////////////////// /1
-	c.Assert(doc.Methods[1].ResultCode, Equals, true)
+	c.Assert(doc.Methods[1].ResultCodes, DeepEquals, map[int]string{0: "ok", 1: "not ok"})
 	c.Assert(doc.Methods[1].ResultEcho, NotNil)
 	c.Assert(doc.Methods[1].ResultEcho.Desc, DeepEquals, []string{"Magic value"})
 	c.Assert(doc.Methods[1].ResultEcho.Type, Equals, script.VariableType(script.VAR_TYPE_BOOLEAN))
@@ -528,7 +1519,7 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[1].Example[0], Equals, "if [[ -f $file ]] ; then")
 	c.Assert(doc.Methods[1].Example[1], Equals, "  method2 123")
 	c.Assert(doc.Methods[1].Example[2], Equals, "fi")
-	c.Assert(doc.Methods[1].Line, Equals, 99)
+	c.Assert(doc.Methods[1].Line, Equals, 101)
 	c.Assert(doc.Methods[1].HasArguments(), Equals, true)
 	c.Assert(doc.Methods[1].HasEcho(), Equals, true)
 	c.Assert(doc.Methods[1].HasExample(), Equals, true)
@@ -543,11 +1534,11 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[2].Arguments[0].Desc, Equals, "First argument")
 	c.Assert(doc.Methods[2].Arguments[0].Type, Equals, script.VariableType(script.VAR_TYPE_STRING))
 	c.Assert(doc.Methods[2].Arguments[0].IsOptional, Equals, true)
-	c.Assert(doc.Methods[2].Arguments[0].IsWildcard, Equals, false)
-	c.Assert(doc.Methods[2].ResultCode, Equals, false)
+	c.Assert(doc.Methods[2].Arguments[0].IsVariadic(), Equals, false)
+	c.Assert(doc.Methods[2].ResultCodes, IsNil)
 	c.Assert(doc.Methods[2].ResultEcho, IsNil)
 	c.Assert(doc.Methods[2].Example, HasLen, 0)
-	c.Assert(doc.Methods[2].Line, Equals, 110)
+	c.Assert(doc.Methods[2].Line, Equals, 112)
 	c.Assert(doc.Methods[2].HasArguments(), Equals, true)
 	c.Assert(doc.Methods[2].HasEcho(), Equals, false)
 	c.Assert(doc.Methods[2].HasExample(), Equals, false)
@@ -557,10 +1548,10 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[3].Name, Equals, "method4")
 	c.Assert(doc.Methods[3].Desc, DeepEquals, []string{"This is desc for method #4."})
 	c.Assert(doc.Methods[3].Arguments, HasLen, 0)
-	c.Assert(doc.Methods[3].ResultCode, Equals, false)
+	c.Assert(doc.Methods[3].ResultCodes, IsNil)
 	c.Assert(doc.Methods[3].ResultEcho, IsNil)
 	c.Assert(doc.Methods[3].Example, HasLen, 0)
-	c.Assert(doc.Methods[3].Line, Equals, 115)
+	c.Assert(doc.Methods[3].Line, Equals, 117)
 	c.Assert(doc.Methods[3].HasArguments(), Equals, false)
 	c.Assert(doc.Methods[3].HasEcho(), Equals, false)
 	c.Assert(doc.Methods[3].HasExample(), Equals, false)
@@ -570,10 +1561,10 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[4].Name, Equals, "method5")
 	c.Assert(doc.Methods[4].Desc, DeepEquals, []string{"This is desc for method #5."})
 	c.Assert(doc.Methods[4].Arguments, HasLen, 0)
-	c.Assert(doc.Methods[4].ResultCode, Equals, false)
+	c.Assert(doc.Methods[4].ResultCodes, IsNil)
 	c.Assert(doc.Methods[4].ResultEcho, IsNil)
 	c.Assert(doc.Methods[4].Example, HasLen, 0)
-	c.Assert(doc.Methods[4].Line, Equals, 121)
+	c.Assert(doc.Methods[4].Line, Equals, 123)
 	c.Assert(doc.Methods[4].HasArguments(), Equals, false)
 	c.Assert(doc.Methods[4].HasEcho(), Equals, false)
 	c.Assert(doc.Methods[4].HasExample(), Equals, false)
@@ -583,10 +1574,10 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[5].Name, Equals, "method6")
 	c.Assert(doc.Methods[5].Desc, DeepEquals, []string{"This is desc for method #6."})
 	c.Assert(doc.Methods[5].Arguments, HasLen, 0)
-	c.Assert(doc.Methods[5].ResultCode, Equals, false)
+	c.Assert(doc.Methods[5].ResultCodes, IsNil)
 	c.Assert(doc.Methods[5].ResultEcho, IsNil)
 	c.Assert(doc.Methods[5].Example, HasLen, 0)
-	c.Assert(doc.Methods[5].Line, Equals, 127)
+	c.Assert(doc.Methods[5].Line, Equals, 129)
 	c.Assert(doc.Methods[5].HasArguments(), Equals, false)
 	c.Assert(doc.Methods[5].HasEcho(), Equals, false)
 	c.Assert(doc.Methods[5].HasExample(), Equals, false)
@@ -601,11 +1592,11 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[6].Arguments[0].Desc, Equals, "First argument")
 	c.Assert(doc.Methods[6].Arguments[0].Type, Equals, script.VariableType(script.VAR_TYPE_UNKNOWN))
 	c.Assert(doc.Methods[6].Arguments[0].IsOptional, Equals, false)
-	c.Assert(doc.Methods[6].Arguments[0].IsWildcard, Equals, false)
-	c.Assert(doc.Methods[6].ResultCode, Equals, false)
+	c.Assert(doc.Methods[6].Arguments[0].IsVariadic(), Equals, false)
+	c.Assert(doc.Methods[6].ResultCodes, IsNil)
 	c.Assert(doc.Methods[6].ResultEcho, IsNil)
 	c.Assert(doc.Methods[6].Example, HasLen, 0)
-	c.Assert(doc.Methods[6].Line, Equals, 134)
+	c.Assert(doc.Methods[6].Line, Equals, 136)
 	c.Assert(doc.Methods[6].HasArguments(), Equals, true)
 	c.Assert(doc.Methods[6].HasEcho(), Equals, false)
 	c.Assert(doc.Methods[6].HasExample(), Equals, false)
@@ -615,11 +1606,11 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[7].Name, Equals, "method8")
 	c.Assert(doc.Methods[7].Desc, DeepEquals, []string{"This is desc for method #8."})
 	c.Assert(doc.Methods[7].Arguments, HasLen, 0)
-	c.Assert(doc.Methods[7].ResultCode, Equals, false)
+	c.Assert(doc.Methods[7].ResultCodes, IsNil)
 	c.Assert(doc.Methods[7].ResultEcho, IsNil)
 	c.Assert(doc.Methods[7].Example, HasLen, 1)
 	c.Assert(doc.Methods[7].Example[0], Equals, "method8 123")
-	c.Assert(doc.Methods[7].Line, Equals, 142)
+	c.Assert(doc.Methods[7].Line, Equals, 144)
 	c.Assert(doc.Methods[7].HasArguments(), Equals, false)
 	c.Assert(doc.Methods[7].HasEcho(), Equals, false)
 	c.Assert(doc.Methods[7].HasExample(), Equals, true)
@@ -629,12 +1620,1167 @@ func (s *ParseSuite) TestParsing(c *C) {
 	c.Assert(doc.Methods[8].Name, Equals, "method9")
 	c.Assert(doc.Methods[8].Desc, DeepEquals, []string{"This is desc for method #9."})
 	c.Assert(doc.Methods[8].Arguments, HasLen, 0)
-	c.Assert(doc.Methods[8].ResultCode, Equals, false)
+	c.Assert(doc.Methods[8].ResultCodes, IsNil)
 	c.Assert(doc.Methods[8].ResultEcho, IsNil)
 	c.Assert(doc.Methods[8].Example, HasLen, 0)
-	c.Assert(doc.Methods[8].Line, Equals, 148)
+	c.Assert(doc.Methods[8].Line, Equals, 150)
 	c.Assert(doc.Methods[8].HasArguments(), Equals, false)
 	c.Assert(doc.Methods[8].HasEcho(), Equals, false)
 	c.Assert(doc.Methods[8].HasExample(), Equals, false)
 	c.Assert(doc.Methods[8].UnitedDesc(), Equals, "This is desc for method #9.")
 }
+
+func (s *ParseSuite) TestFunctionDefinitions(c *C) {
+	doc, errs := readData("funcs.sh", strings.NewReader(_SCRIPT_FUNCS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(errs, HasLen, 0)
+
+	c.Assert(doc.Methods, HasLen, 6)
+	c.Assert(errs, HasLen, 0)
+
+	for i, line := range []int{4, 9, 14, 19, 25, 31} {
+		c.Assert(doc.Methods[i].Name, Equals, fmt.Sprintf("method%d", i+1))
+		c.Assert(doc.Methods[i].Line, Equals, line)
+	}
+}
+
+func (s *ParseSuite) TestDeclarations(c *C) {
+	doc, errs := readData("decls.sh", strings.NewReader(_SCRIPT_DECLS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(errs, HasLen, 0)
+
+	c.Assert(doc.Constants, HasLen, 4)
+	c.Assert(doc.Variables, HasLen, 4)
+
+	c.Assert(doc.Constants[0].Name, Equals, "MAX_RETRIES")
+	c.Assert(doc.Constants[0].Value, Equals, "5")
+	c.Assert(doc.Constants[0].IsReadOnly, Equals, true)
+	c.Assert(doc.Constants[0].Line, Equals, 4)
+
+	c.Assert(doc.Constants[1].Name, Equals, "max_delay")
+	c.Assert(doc.Constants[1].Value, Equals, "10")
+	c.Assert(doc.Constants[1].IsReadOnly, Equals, true)
+
+	c.Assert(doc.Constants[2].Name, Equals, "MAP")
+	c.Assert(doc.Constants[2].IsAssoc, Equals, true)
+	c.Assert(doc.Constants[2].IsReadOnly, Equals, false)
+
+	c.Assert(doc.Constants[3].Name, Equals, "mode")
+	c.Assert(doc.Constants[3].IsReadOnly, Equals, true)
+	c.Assert(doc.Constants[3].IsExported, Equals, false)
+
+	c.Assert(doc.Variables[0].Name, Equals, "path_prefix")
+	c.Assert(doc.Variables[0].Value, Equals, "\"/usr\"")
+	c.Assert(doc.Variables[0].IsExported, Equals, true)
+	c.Assert(doc.Variables[0].Type, Equals, script.VariableType(script.VAR_TYPE_STRING))
+
+	c.Assert(doc.Variables[1].Name, Equals, "count")
+	c.Assert(doc.Variables[1].IsInteger, Equals, true)
+	c.Assert(doc.Variables[1].Type, Equals, script.VariableType(script.VAR_TYPE_NUMBER))
+
+	c.Assert(doc.Variables[2].Name, Equals, "total")
+	c.Assert(doc.Variables[2].IsInteger, Equals, true)
+	c.Assert(doc.Variables[2].IsExported, Equals, true)
+	c.Assert(doc.Variables[2].Value, Equals, "")
+	c.Assert(doc.Variables[2].Type, Equals, script.VariableType(script.VAR_TYPE_NUMBER))
+
+	c.Assert(doc.Variables[3].Name, Equals, "hosts")
+	c.Assert(doc.Variables[3].IsIndexed, Equals, true)
+}
+
+func (s *ParseSuite) TestArrays(c *C) {
+	doc, errs := readData("arrays.sh", strings.NewReader(_SCRIPT_ARRAYS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(errs, HasLen, 0)
+
+	c.Assert(doc.Constants, HasLen, 6)
+
+	c.Assert(doc.Constants[0].Name, Equals, "HOSTS")
+	c.Assert(doc.Constants[0].Type, Equals, script.VariableType(script.VAR_TYPE_ARRAY))
+	c.Assert(doc.Constants[0].Value, Equals, "(a b c)")
+	c.Assert(doc.Constants[0].Elements, DeepEquals, []*script.Element{
+		{Value: "a"}, {Value: "b"}, {Value: "c"},
+	})
+
+	c.Assert(doc.Constants[1].Name, Equals, "PORTS")
+	c.Assert(doc.Constants[1].Type, Equals, script.VariableType(script.VAR_TYPE_ARRAY))
+	c.Assert(doc.Constants[1].Line, Equals, 7)
+	c.Assert(doc.Constants[1].Elements, DeepEquals, []*script.Element{
+		{Value: "80"}, {Value: "\"443\""}, {Value: "'some value'"},
+	})
+
+	c.Assert(doc.Constants[2].Name, Equals, "MAP")
+	c.Assert(doc.Constants[2].Type, Equals, script.VariableType(script.VAR_TYPE_MAP))
+	c.Assert(doc.Constants[2].Elements, DeepEquals, []*script.Element{
+		{Key: "key1", Value: "value1"}, {Key: "key2", Value: "\"value 2\""},
+	})
+
+	c.Assert(doc.Constants[3].Name, Equals, "OPTS")
+	c.Assert(doc.Constants[3].Type, Equals, script.VariableType(script.VAR_TYPE_MAP))
+	c.Assert(doc.Constants[3].Desc, DeepEquals, []string{"Associative array with multiline definition"})
+	c.Assert(doc.Constants[3].Elements, DeepEquals, []*script.Element{
+		{Key: "verbose", Value: "true"}, {Key: "level", Value: "$(get_level \"a b\")"},
+	})
+
+	c.Assert(doc.Constants[4].Name, Equals, "IDX")
+	c.Assert(doc.Constants[4].Type, Equals, script.VariableType(script.VAR_TYPE_ARRAY))
+	c.Assert(doc.Constants[4].Elements, DeepEquals, []*script.Element{
+		{Key: "0", Value: "zero"}, {Key: "3", Value: "three"},
+	})
+
+	c.Assert(doc.Constants[5].Name, Equals, "VAR_AFTER")
+	c.Assert(doc.Constants[5].Line, Equals, 26)
+}
+
+func (s *ParseSuite) TestHeredocs(c *C) {
+	doc, errs := readData("heredocs.sh", strings.NewReader(_SCRIPT_HEREDOCS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(errs, HasLen, 0)
+
+	c.Assert(doc.Methods, HasLen, 7)
+
+	for i, line := range []int{4, 12, 20, 29, 37, 43, 48} {
+		c.Assert(doc.Methods[i].Name, Equals, fmt.Sprintf("method%d", i+1))
+		c.Assert(doc.Methods[i].Line, Equals, line)
+	}
+}
+
+func (s *ParseSuite) TestLexer(c *C) {
+	l := &lexer{}
+
+	l.Scan(`echo 'a "b'`)
+	c.Assert(l.IsOpen(), Equals, false)
+	l.Scan(`echo $'it\'s' "$(echo ")")" ${a:-"}"}`)
+	c.Assert(l.IsOpen(), Equals, false)
+	l.Scan(`echo "a # b`)
+	c.Assert(l.IsOpen(), Equals, true)
+	l.Scan(`c"`)
+	c.Assert(l.IsOpen(), Equals, false)
+	l.Scan(`echo a \`)
+	c.Assert(l.IsOpen(), Equals, true)
+	l.Scan(`b # it's comment`)
+	c.Assert(l.IsOpen(), Equals, false)
+	l.Scan("echo `date` $(( (1 + 2) << 3 ))")
+	c.Assert(l.IsOpen(), Equals, false)
+	c.Assert(l.InHeredoc(), Equals, false)
+
+	l.Scan(`cat <<A <<-"B"; echo "x"`)
+	c.Assert(l.IsOpen(), Equals, false)
+	c.Assert(l.InHeredoc(), Equals, true)
+	l.Feed("A")
+	c.Assert(l.InHeredoc(), Equals, true)
+	l.Feed("\tB")
+	c.Assert(l.InHeredoc(), Equals, false)
+
+	l.Scan(`cat << `)
+	c.Assert(l.InHeredoc(), Equals, false)
+}
+
+func (s *ParseSuite) TestValues(c *C) {
+	doc, errs := readData("values.sh", strings.NewReader(_SCRIPT_VALUES))
+
+	c.Assert(doc, NotNil)
+	c.Assert(errs, HasLen, 0)
+
+	c.Assert(doc.Constants, HasLen, 9)
+
+	c.Assert(doc.Constants[0].Value, Equals, `"value"`)
+	c.Assert(doc.Constants[1].Value, Equals, `10`)
+	c.Assert(doc.Constants[1].Type, Equals, script.VariableType(script.VAR_TYPE_NUMBER))
+	c.Assert(doc.Constants[2].Value, Equals, "'first \"line\"\nsecond line'")
+	c.Assert(doc.Constants[3].Value, Equals, "\"some \\\"quoted\\\"\ntext\"")
+	c.Assert(doc.Constants[4].Value, Equals, "$(echo \"a\" |\n  tr 'a' 'b')")
+	c.Assert(doc.Constants[5].Value, Equals, "abc\\\ndef")
+	c.Assert(doc.Constants[6].Value, Equals, `#abc`)
+	c.Assert(doc.Constants[7].Value, Equals, `"${HOME:-"/root"}/dir"`)
+	c.Assert(doc.Constants[8].Value, Equals, `1`)
+	c.Assert(doc.Constants[8].Line, Equals, 32)
+}
+
+func (s *ParseSuite) TestDiagnostics(c *C) {
+	doc, diags := readData("diags.sh", strings.NewReader(_SCRIPT_DIAGS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Constants, HasLen, 1)
+	c.Assert(doc.Methods, HasLen, 1)
+	c.Assert(diags, HasLen, 8)
+	c.Assert(diags.IsEmpty(), Equals, false)
+	c.Assert(diags.HasErrors(), Equals, true)
+
+	c.Assert(diags[0], DeepEquals, &Diagnostic{"diags.sh", 3, 30, SEVERITY_WARNING, RULE_UNKNOWN_TYPE, `Unknown type marker "Strng"`, ""})
+	c.Assert(diags[1], DeepEquals, &Diagnostic{"diags.sh", 8, 21, SEVERITY_WARNING, RULE_UNKNOWN_TYPE, `Unknown type marker "Strng"`, ""})
+	c.Assert(diags[2], DeepEquals, &Diagnostic{"diags.sh", 9, 3, SEVERITY_ERROR, RULE_DUPLICATE_ARGUMENT, "Argument 1 is documented more than once", ""})
+	c.Assert(diags[3], DeepEquals, &Diagnostic{"diags.sh", 10, 3, SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT, "Argument 2 doesn't have description", ""})
+	c.Assert(diags[4], DeepEquals, &Diagnostic{"diags.sh", 12, 3, SEVERITY_WARNING, RULE_EMPTY_EXAMPLE, "Example block doesn't contain any code", ""})
+
+	c.Assert(diags[5], DeepEquals, &Diagnostic{"diags.sh", 8, 3, SEVERITY_WARNING, RULE_UNUSED_ARGUMENT, "Argument 1 of method method1 is documented but never used", ""})
+	c.Assert(diags[6], DeepEquals, &Diagnostic{"diags.sh", 10, 3, SEVERITY_WARNING, RULE_UNUSED_ARGUMENT, "Argument 2 of method method1 is documented but never used", ""})
+
+	c.Assert(diags[7], DeepEquals, &Diagnostic{"diags.sh", 18, 1, SEVERITY_NOTICE, RULE_MISSING_DOCS, "Method helper doesn't have documentation", "helper"})
+
+	c.Assert(diags[0].Error(), Equals, `diags.sh:3:30: warning: Unknown type marker "Strng" [unknown-type]`)
+	c.Assert(diags[2].IsError(), Equals, true)
+	c.Assert(diags[4].IsError(), Equals, false)
+
+	longLine := "# Constant\nCONST_1=1\n\nVAR=\"" + strings.Repeat("A", 70000) + "\"\n"
+	doc, diags = readData("long.sh", strings.NewReader("#!/bin/bash\n\n"+longLine))
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Constants, HasLen, 1)
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0].Line, Equals, 6)
+	c.Assert(diags[0].Rule, Equals, RULE_SCAN_ERROR)
+	c.Assert(diags.HasErrors(), Equals, true)
+
+	var d *Diagnostic
+
+	c.Assert(d.Error(), Equals, "")
+	c.Assert(d.IsError(), Equals, false)
+	c.Assert(Diagnostics{}.IsEmpty(), Equals, true)
+	c.Assert(Diagnostics{}.HasErrors(), Equals, false)
+	c.Assert(SEVERITY_NOTICE.String(), Equals, "notice")
+	c.Assert(SEVERITY_WARNING.String(), Equals, "warning")
+	c.Assert(SEVERITY_ERROR.String(), Equals, "error")
+	c.Assert(Severity(0).String(), Equals, "unknown")
+	c.Assert((&Diagnostic{File: "test.sh", Severity: SEVERITY_ERROR, Rule: "test", Message: "Test"}).Error(), Equals, "test.sh: error: Test [test]")
+}
+
+func (s *ParseSuite) TestArgumentsUsage(c *C) {
+	doc, diags := readData("params.sh", strings.NewReader(_SCRIPT_PARAMS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Methods, HasLen, 6)
+	c.Assert(diags, HasLen, 5)
+
+	c.Assert(diags[0], DeepEquals, &Diagnostic{"params.sh", 14, 9, SEVERITY_WARNING, RULE_UNDOCUMENTED_ARGUMENT, "Argument 5 of method method1 is used but not documented", ""})
+	c.Assert(diags[1], DeepEquals, &Diagnostic{"params.sh", 7, 3, SEVERITY_WARNING, RULE_UNUSED_ARGUMENT, "Argument 3 of method method1 is documented but never used", ""})
+	c.Assert(diags[2], DeepEquals, &Diagnostic{"params.sh", 22, 18, SEVERITY_WARNING, RULE_MISSING_WILDCARD, "Method method2 uses all arguments but wildcard argument is not documented", ""})
+	c.Assert(diags[3], DeepEquals, &Diagnostic{"params.sh", 53, 3, SEVERITY_WARNING, RULE_UNUSED_ARGUMENT, "Argument 1..2 of method method6 is documented but never used", ""})
+	c.Assert(diags[4], DeepEquals, &Diagnostic{"params.sh", 54, 3, SEVERITY_WARNING, RULE_UNUSED_ARGUMENT, "Argument 3..* of method method6 is documented but never used", ""})
+
+	_, diags = readData("params.sh", strings.NewReader(_SCRIPT_PARAMS_ANNOTATED))
+
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0], DeepEquals, &Diagnostic{"params.sh", 5, 9, SEVERITY_WARNING, RULE_UNUSED_ARGUMENT, "Argument 2 of method connect is documented but never used", ""})
+
+	// Arguments documented in other dialect are not parsed, so they are
+	// reported only as undocumented
+	_, _, _, diags = readScript("params.sh", []byte(_SCRIPT_PARAMS), &Options{Dialect: findDialect(DIALECT_ANNOTATED)})
+
+	c.Assert(diags, HasLen, 9)
+	c.Assert(skipRules(diags, RULE_UNDOCUMENTED_ARGUMENT, RULE_MISSING_WILDCARD), HasLen, 0)
+
+	index, ok := parseParamRef("$10", 0)
+	c.Assert(index, Equals, 1)
+	c.Assert(ok, Equals, true)
+	index, ok = parseParamRef("${10}", 0)
+	c.Assert(index, Equals, 10)
+	c.Assert(ok, Equals, true)
+	index, ok = parseParamRef("${@:2}", 0)
+	c.Assert(index, Equals, 0)
+	c.Assert(ok, Equals, true)
+	_, ok = parseParamRef("${name}", 0)
+	c.Assert(ok, Equals, false)
+	_, ok = parseParamRef("$#", 0)
+	c.Assert(ok, Equals, false)
+	c.Assert(stripComment(`echo "#1" '#2' \#3 # comment`), Equals, `echo "#1" '#2' \#3 `)
+}
+
+func (s *ParseSuite) TestCalls(c *C) {
+	doc, diags := readData("calls.sh", strings.NewReader(_SCRIPT_CALLS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Methods, HasLen, 5)
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0].Rule, Equals, RULE_MISSING_DOCS)
+
+	c.Assert(doc.Methods[0].Calls, DeepEquals, []string{"getValue", "checkValue", "printValue"})
+	c.Assert(doc.Methods[0].CalledBy, IsNil)
+	c.Assert(doc.Methods[1].Calls, IsNil)
+	c.Assert(doc.Methods[1].CalledBy, DeepEquals, []string{"main"})
+	c.Assert(doc.Methods[2].Calls, IsNil)
+	c.Assert(doc.Methods[2].CalledBy, IsNil)
+	c.Assert(doc.Methods[3].Calls, DeepEquals, []string{"printValue"})
+	c.Assert(doc.Methods[3].CalledBy, DeepEquals, []string{"main"})
+	c.Assert(doc.Methods[4].Calls, IsNil)
+	c.Assert(doc.Methods[4].CalledBy, DeepEquals, []string{"main", "checkValue"})
+
+	c.Assert(ExtractCommands(`# comment`), HasLen, 0)
+	c.Assert(ExtractCommands(`A=1 B=2`), HasLen, 0)
+	c.Assert(ExtractCommands(`greet "Hello" | grep -q Hello && echo ok`), DeepEquals, []string{"greet", "grep", "echo"})
+	c.Assert(ExtractCommands(`if ! check 1 ; then run ; fi`), DeepEquals, []string{"check", "run"})
+	c.Assert(ExtractCommands(`result=$(getValue 1)`), DeepEquals, []string{"getValue"})
+	c.Assert(ExtractCommands(`./script.sh --help`), HasLen, 0)
+}
+
+func (s *ParseSuite) TestTags(c *C) {
+	doc, diags := readData("tags.sh", strings.NewReader(_SCRIPT_TAGS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Constants, HasLen, 1)
+	c.Assert(doc.Methods, HasLen, 2)
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0].Rule, Equals, RULE_UNKNOWN_TAG)
+	c.Assert(diags[0].Message, Equals, "Unknown tag @version")
+	c.Assert(diags[0].Line, Equals, 16)
+	c.Assert(diags[0].Column, Equals, 3)
+
+	v := doc.Constants[0]
+
+	c.Assert(v.Desc, DeepEquals, []string{"Path to temporary directory"})
+	c.Assert(v.Type, Equals, script.VAR_TYPE_STRING)
+	c.Assert(v.IsDeprecated(), Equals, true)
+	c.Assert(v.Deprecated.Replacement, Equals, "")
+	c.Assert(v.Deprecated.Note, Equals, "Use TMPDIR variable")
+	c.Assert(v.Since, Equals, "1.2.0")
+
+	m := doc.Methods[0]
+
+	c.Assert(m.Desc, DeepEquals, []string{"Download file"})
+	c.Assert(m.Arguments, HasLen, 1)
+	c.Assert(m.Example, DeepEquals, []string{"  @download https://domain.com/file.txt"})
+	c.Assert(m.IsDeprecated(), Equals, true)
+	c.Assert(m.Deprecated.Replacement, Equals, "fetch")
+	c.Assert(m.Deprecated.Note, Equals, "")
+	c.Assert(m.Since, Equals, "1.0.0")
+	c.Assert(m.See, DeepEquals, []string{"fetch", "https://curl.se"})
+	c.Assert(m.Author, Equals, "John Doe, Jane Doe")
+	c.Assert(m.HasTags(), Equals, true)
+
+	m = doc.Methods[1]
+
+	c.Assert(m.IsDeprecated(), Equals, false)
+	c.Assert(m.HasSee(), Equals, false)
+	c.Assert(m.Since, Equals, "2.0.0")
+
+	_, tags := parseTags([]string{"Description", "@deprecated", "@see"})
+
+	c.Assert(tags.Deprecated, NotNil)
+	c.Assert(tags.Deprecated.Replacement, Equals, "")
+	c.Assert(tags.See, IsNil)
+	c.Assert(isTagLine("@since 1.0.0"), Equals, true)
+	c.Assert(isTagLine("user@domain.com"), Equals, false)
+}
+
+func (s *ParseSuite) TestCodes(c *C) {
+	doc, diags := readData("codes.sh", strings.NewReader(_SCRIPT_CODES))
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 0)
+	c.Assert(doc.Methods, HasLen, 3)
+
+	c.Assert(doc.Methods[0].ResultCodes, DeepEquals, map[int]string{
+		0: "ok", 1: "download error", 2: "bad arguments",
+	})
+
+	c.Assert(doc.Methods[1].ResultCodes, DeepEquals, map[int]string{
+		0: "ok", 2: "bad arguments", 3: "network error", 10: "server error - try again later",
+	})
+	c.Assert(doc.Methods[1].ResultEcho, NotNil)
+	c.Assert(doc.Methods[1].ResultEcho.Desc, DeepEquals, []string{"Server response"})
+
+	c.Assert(doc.Methods[2].ResultCodes, DeepEquals, map[int]string{0: "ok", 1: "not ok"})
+	c.Assert(doc.Methods[2].Codes(), DeepEquals, []int{0, 1})
+}
+
+func (s *ParseSuite) TestCodesRange(c *C) {
+	_, diags := readData("codes.sh", strings.NewReader(_SCRIPT_CODES_RANGE))
+
+	c.Assert(diags, HasLen, 2)
+	c.Assert(diags[0], DeepEquals, &Diagnostic{"codes.sh", 5, 17, SEVERITY_WARNING, RULE_INVALID_CODE, "Exit code 300 is out of range 0..255", ""})
+	c.Assert(diags[1], DeepEquals, &Diagnostic{"codes.sh", 6, 5, SEVERITY_WARNING, RULE_INVALID_CODE, "Exit code 256 is out of range 0..255", ""})
+
+	_, diags = readData("codes.sh", strings.NewReader(_SCRIPT_CODES_ANNOTATED))
+
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0], DeepEquals, &Diagnostic{"codes.sh", 5, 13, SEVERITY_WARNING, RULE_INVALID_CODE, "Exit code 1024 is out of range 0..255", ""})
+}
+
+func (s *ParseSuite) TestOutput(c *C) {
+	doc, diags := readData("output.sh", strings.NewReader(_SCRIPT_OUTPUT))
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 0)
+	c.Assert(doc.Methods, HasLen, 2)
+
+	m := doc.Methods[0]
+
+	c.Assert(m.Desc, DeepEquals, []string{"List hosts"})
+	c.Assert(m.Arguments, HasLen, 1)
+	c.Assert(m.ResultEcho, NotNil)
+	c.Assert(m.ResultEcho.Type, Equals, script.VAR_TYPE_STRING)
+	c.Assert(m.ResultEcho.Desc, DeepEquals, []string{
+		"Hosts list", "one host per line, tab-separated", "name, address and port",
+	})
+	c.Assert(m.Stderr, DeepEquals, []string{"Error message if inventory", "can't be read"})
+	c.Assert(m.Files, HasLen, 2)
+	c.Assert(m.Files[0].Path, Equals, "/tmp/hosts.cache")
+	c.Assert(m.Files[0].Desc, Equals, "Cache with hosts")
+	c.Assert(m.Files[1].Path, Equals, "~/.hosts_history")
+	c.Assert(m.Files[1].Desc, Equals, "")
+	c.Assert(m.Example, DeepEquals, []string{"  listHosts inventory.ini"})
+
+	m = doc.Methods[1]
+
+	c.Assert(m.ResultEcho, IsNil)
+	c.Assert(m.HasStderr(), Equals, false)
+	c.Assert(m.Files, HasLen, 1)
+	c.Assert(m.Files[0].Path, Equals, "/etc/hosts")
+	c.Assert(m.Files[0].Desc, Equals, "System hosts file")
+}
+
+func (s *ParseSuite) TestArgumentsHeredoc(c *C) {
+	doc, diags := readData("heredoc.sh", strings.NewReader(_SCRIPT_PARAMS_HEREDOC))
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Methods, HasLen, 2)
+	c.Assert(diags, HasLen, 0)
+
+	lx := &lexer{}
+
+	lx.Feed("cat <<EOF")
+	c.Assert(lx.IsExpanding(), Equals, true)
+
+	lx = &lexer{}
+
+	lx.Feed(`cat <<"EOF"`)
+	c.Assert(lx.InHeredoc(), Equals, true)
+	c.Assert(lx.IsExpanding(), Equals, false)
+
+	lx = &lexer{}
+
+	lx.Feed(`cat <<\EOF`)
+	c.Assert(lx.IsExpanding(), Equals, false)
+}
+
+func (s *ParseSuite) TestVariablesUsage(c *C) {
+	doc, diags := readData("vars.sh", strings.NewReader(_SCRIPT_VARS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 0)
+	c.Assert(doc.Methods, HasLen, 3)
+
+	m := doc.Methods[0]
+
+	c.Assert(m.Reads, HasLen, 4)
+	c.Assert(m.Reads[0], DeepEquals, &script.VariableRef{Name: "HTTP_PROXY", Desc: "Proxy URL"})
+	c.Assert(m.Reads[1], DeepEquals, &script.VariableRef{Name: "NO_PROXY"})
+	c.Assert(m.Reads[2], DeepEquals, &script.VariableRef{Name: "CURL_HOME"})
+	c.Assert(m.Reads[3], DeepEquals, &script.VariableRef{Name: "MAX_RETRIES", IsDetected: true})
+	c.Assert(m.Modifies, HasLen, 1)
+	c.Assert(m.Modifies[0], DeepEquals, &script.VariableRef{Name: "LAST_ERROR", Desc: "Error message"})
+
+	m = doc.Methods[1]
+
+	c.Assert(m.Reads, IsNil)
+	c.Assert(m.Modifies, HasLen, 3)
+	c.Assert(m.Modifies[0].Name, Equals, "LAST_ERROR")
+	c.Assert(m.Modifies[1].Name, Equals, "REQUESTS")
+	c.Assert(m.Modifies[2].Name, Equals, "MAX_RETRIES")
+
+	m = doc.Methods[2]
+
+	c.Assert(m.Reads, HasLen, 1)
+	c.Assert(m.Reads[0].Name, Equals, "REQUESTS")
+	c.Assert(m.Modifies, IsNil)
+
+	doc, diags = readData("state.sh", strings.NewReader(_SCRIPT_STATE))
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 0)
+	c.Assert(doc.Methods, HasLen, 1)
+	c.Assert(doc.Methods[0].Reads, IsNil)
+	c.Assert(doc.Methods[0].Modifies, HasLen, 1)
+	c.Assert(doc.Methods[0].Modifies[0].Name, Equals, "STATE")
+
+	writes, locals := parseAssignments(`echo FOO=bar`)
+	c.Assert(writes, IsNil)
+	c.Assert(locals, IsNil)
+	writes, _ = parseAssignments(`cmd --x FOO=1 ; printf '%s' X=1`)
+	c.Assert(writes, IsNil)
+	writes, _ = parseAssignments(`FOO=1 cmd`)
+	c.Assert(writes, IsNil)
+	writes, _ = parseAssignments(`A=1 B+=2 && arr[1]=2`)
+	c.Assert(writes, DeepEquals, []string{"A", "B", "arr"})
+	writes, _ = parseAssignments(`if true ; then X="a b; c" ; fi`)
+	c.Assert(writes, DeepEquals, []string{"X"})
+	writes, _ = parseAssignments(`echo "X=1; Y=2"`)
+	c.Assert(writes, IsNil)
+	writes, locals = parseAssignments(`declare x=1 ; typeset -i n=0 ; local -r y`)
+	c.Assert(writes, IsNil)
+	c.Assert(locals, DeepEquals, []string{"x", "n", "y"})
+	writes, locals = parseAssignments(`declare -g G=1 ; export PATH="$PATH:/bin" ; export HOME`)
+	c.Assert(writes, DeepEquals, []string{"G", "PATH"})
+	c.Assert(locals, IsNil)
+	writes, _ = parseAssignments(`unset -v V ; unset -f fn`)
+	c.Assert(writes, DeepEquals, []string{"V"})
+
+	c.Assert(parseVarRef("$HOME", 0), Equals, "HOME")
+	c.Assert(parseVarRef("${#ITEMS[@]}", 0), Equals, "ITEMS")
+	c.Assert(parseVarRef("${!REF}", 0), Equals, "REF")
+	c.Assert(parseVarRef("$1", 0), Equals, "")
+}
+
+func (s *ParseSuite) TestArgumentsNames(c *C) {
+	doc, diags := readData("names.sh", strings.NewReader(_SCRIPT_NAMES))
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 0)
+	c.Assert(doc.Methods, HasLen, 2)
+
+	args := doc.Methods[0].Arguments
+
+	c.Assert(args, HasLen, 5)
+	c.Assert(args[0].Name, Equals, "host")
+	c.Assert(args[0].Desc, Equals, "Target host")
+	c.Assert(args[0].Type, Equals, script.VAR_TYPE_STRING)
+	c.Assert(args[1].Name, Equals, "port")
+	c.Assert(args[1].Desc, Equals, "Target port")
+	c.Assert(args[1].IsOptional, Equals, true)
+	c.Assert(args[2].Name, Equals, "user")
+	c.Assert(args[3].Name, Equals, "timeout")
+	c.Assert(args[4].Name, Equals, "opts")
+	c.Assert(args[4].IsVariadic(), Equals, true)
+
+	c.Assert(doc.Methods[0].Signature(), Equals, "connect host [port] [user] [timeout] opts…")
+
+	args = doc.Methods[1].Arguments
+
+	c.Assert(args[0].Name, Equals, "msg")
+	c.Assert(args[1].Name, Equals, "color")
+	c.Assert(doc.Methods[1].Signature(), Equals, "print msg color")
+
+	c.Assert(isArgumentLine("1 host: Target host"), Equals, true)
+	c.Assert(isArgumentLine("Supports 2 modes: fast and slow"), Equals, false)
+	c.Assert(getArgumentLoc("1: Target host"), DeepEquals, []int{0, 14, 0, 1, -1, -1, 3, 14})
+}
+
+func (s *ParseSuite) TestArgumentsConstraints(c *C) {
+	doc, diags := readData("constraints.sh", strings.NewReader(_SCRIPT_CONSTRAINTS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Methods, HasLen, 1)
+
+	args := doc.Methods[0].Arguments
+
+	c.Assert(args, HasLen, 6)
+
+	c.Assert(args[0].Desc, Equals, "Action")
+	c.Assert(args[0].Type, Equals, script.VAR_TYPE_STRING)
+	c.Assert(args[0].Enum, DeepEquals, []string{"start", "stop", "restart"})
+	c.Assert(args[0].Default, Equals, "start")
+	c.Assert(args[0].IsOptional, Equals, true)
+
+	c.Assert(args[1].Desc, Equals, "Port")
+	c.Assert(args[1].Type, Equals, script.VAR_TYPE_NUMBER)
+	c.Assert(*args[1].Min, Equals, 1)
+	c.Assert(*args[1].Max, Equals, 65535)
+	c.Assert(args[1].Default, Equals, "8080")
+	c.Assert(args[1].IsOptional, Equals, true)
+
+	c.Assert(args[2].Desc, Equals, "Number of retries")
+	c.Assert(*args[2].Min, Equals, 0)
+	c.Assert(args[2].Max, IsNil)
+	c.Assert(args[2].Default, Equals, "")
+	c.Assert(args[2].IsOptional, Equals, true)
+
+	c.Assert(doc.Methods[0].Signature(), Equals, "service [action=start] [port=8080] [retries] [mode=medium] [timeout=abc] level")
+
+	c.Assert(diags, HasLen, 4)
+	c.Assert(diags[0].Rule, Equals, RULE_INVALID_DEFAULT)
+	c.Assert(diags[0].Message, Equals, `Default value "medium" of argument 4 is not in the list of allowed values`)
+	c.Assert(diags[1].Rule, Equals, RULE_MALFORMED_ARGUMENT)
+	c.Assert(diags[1].Message, Equals, "Argument 5 has invalid range 10..1")
+	c.Assert(diags[2].Rule, Equals, RULE_INVALID_DEFAULT)
+	c.Assert(diags[2].Message, Equals, `Default value "abc" of argument 5 is not a number`)
+	c.Assert(diags[3].Rule, Equals, RULE_UNKNOWN_TYPE)
+	c.Assert(diags[3].Line, Equals, 10)
+	c.Assert(diags[3].Column, Equals, 18)
+}
+
+func (s *ParseSuite) TestTypes(c *C) {
+	_, err := script.RegisterType("Host", "#FF8800")
+	c.Assert(err, IsNil)
+
+	doc, diags := readData("types.sh", strings.NewReader(_SCRIPT_TYPES))
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Constants, HasLen, 2)
+	c.Assert(doc.Methods, HasLen, 1)
+
+	c.Assert(doc.Constants[0].Desc, DeepEquals, []string{"Path to configuration file"})
+	c.Assert(doc.Constants[0].Type, Equals, script.VAR_TYPE_PATH)
+	c.Assert(doc.Constants[1].Type, Equals, script.VAR_TYPE_URL)
+	c.Assert(doc.Constants[1].TypeName(0), Equals, "URL")
+
+	args := doc.Methods[0].Arguments
+
+	c.Assert(args, HasLen, 4)
+	c.Assert(args[0].Type, Equals, script.VAR_TYPE_FILE)
+	c.Assert(args[1].Type, Equals, script.VAR_TYPE_DIR)
+	c.Assert(args[2].Type, Equals, script.FindType("Host"))
+	c.Assert(args[2].TypeColor(), Equals, "#FF8800")
+	c.Assert(args[3].Type, Equals, script.VAR_TYPE_UNKNOWN)
+
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0].Rule, Equals, RULE_UNKNOWN_TYPE)
+	c.Assert(diags[0].Message, Equals, `Unknown type marker "Speed"`)
+}
+
+func (s *ParseSuite) TestArgumentsRanges(c *C) {
+	doc, diags := readData("ranges.sh", strings.NewReader(_SCRIPT_RANGES))
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Methods, HasLen, 3)
+
+	args := doc.Methods[0].Arguments
+
+	c.Assert(args, HasLen, 2)
+	c.Assert(args[1].Index, Equals, "2..*")
+	c.Assert(args[1].Name, Equals, "items")
+	c.Assert(args[1].Desc, Equals, "Items to join")
+	c.Assert(args[1].From, Equals, 2)
+	c.Assert(args[1].To, Equals, script.ARG_INDEX_ANY)
+	c.Assert(args[1].IsVariadic(), Equals, true)
+
+	c.Assert(doc.Methods[0].Signature(), Equals, "join_by sep items…")
+
+	args = doc.Methods[1].Arguments
+
+	c.Assert(args, HasLen, 3)
+	c.Assert(args[1].Index, Equals, "2..3")
+	c.Assert(args[1].Name, Equals, "option")
+	c.Assert(args[1].From, Equals, 2)
+	c.Assert(args[1].To, Equals, 3)
+	c.Assert(args[1].IsVariadic(), Equals, false)
+	c.Assert(args[1].IsMultiple(), Equals, true)
+	c.Assert(args[2].Index, Equals, "4..*")
+	c.Assert(args[2].From, Equals, 4)
+	c.Assert(args[2].IsVariadic(), Equals, true)
+
+	c.Assert(doc.Methods[1].Signature(), Equals, "set_options arg1 option… args…")
+
+	c.Assert(diags, HasLen, 2)
+	c.Assert(diags[0].Rule, Equals, RULE_MALFORMED_ARGUMENT)
+	c.Assert(diags[0].Message, Equals, "Argument 3..2 has invalid index range")
+	c.Assert(diags[1].Rule, Equals, RULE_MALFORMED_ARGUMENT)
+	c.Assert(diags[1].Message, Equals, "Argument index must be greater than 0")
+}
+
+func (s *ParseSuite) TestMultilineDesc(c *C) {
+	doc, diags := readData("multiline.sh", strings.NewReader(_SCRIPT_MULTILINE))
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 0)
+	c.Assert(doc.Methods, HasLen, 1)
+
+	m := doc.Methods[0]
+
+	c.Assert(m.Desc, DeepEquals, []string{"Sync directories"})
+	c.Assert(m.Arguments, HasLen, 2)
+	c.Assert(m.Arguments[0].Type, Equals, script.VAR_TYPE_DIR)
+	c.Assert(m.Arguments[0].DescLines(), DeepEquals, []string{
+		"Source directory, all files from this",
+		"directory will be copied",
+		"",
+		"Symlinks are ignored",
+	})
+	c.Assert(m.Arguments[1].Desc, Equals, "Target directory")
+
+	c.Assert(m.Codes(), DeepEquals, []int{0, 1, 2})
+	c.Assert(m.ResultCodes[0], Equals, "synced")
+	c.Assert(m.CodeLines(1), DeepEquals, []string{
+		"source directory", "doesn't exist", "", "Check the path",
+	})
+	c.Assert(m.ResultCodes[2], Equals, "failed")
+
+	c.Assert(m.ResultEcho, NotNil)
+	c.Assert(m.ResultEcho.Type, Equals, script.VAR_TYPE_STRING)
+	c.Assert(m.ResultEcho.Desc, DeepEquals, []string{
+		"List of copied files", "", "One file per line",
+	})
+
+	codes, lines := parseCodes("0 - ok", []string{"", "  continuation", "Echo: yes"})
+
+	c.Assert(codes, DeepEquals, map[int]string{0: "ok\n\ncontinuation"})
+	c.Assert(lines, Equals, 2)
+
+	codes, lines = parseCodes("", []string{"  text", "Echo: yes"})
+
+	c.Assert(codes, DeepEquals, map[int]string{0: "ok", 1: "not ok"})
+	c.Assert(lines, Equals, 0)
+
+	desc, lines := getContinuationLines([]string{"  line", "", "", "  next"})
+
+	c.Assert(desc, DeepEquals, []string{"line"})
+	c.Assert(lines, Equals, 1)
+}
+
+func (s *ParseSuite) TestSections(c *C) {
+	doc, diags := readData("sections.sh", strings.NewReader(_SCRIPT_SECTIONS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 0)
+	c.Assert(doc.About, DeepEquals, []string{"Library with helpers"})
+	c.Assert(doc.Methods, HasLen, 4)
+	c.Assert(doc.Sections, HasLen, 2)
+
+	net := doc.Sections[0]
+
+	c.Assert(net.Name, Equals, "Networking")
+	c.Assert(net.Path, Equals, "Networking")
+	c.Assert(net.Desc, DeepEquals, []string{"Network helpers"})
+	c.Assert(net.Line, Equals, 5)
+	c.Assert(net.File, Equals, "sections.sh")
+	c.Assert(net.Constants, HasLen, 1)
+	c.Assert(net.Constants[0].Name, Equals, "PORT")
+	c.Assert(net.Constants[0].Section, Equals, "Networking")
+	c.Assert(net.Methods, HasLen, 2)
+	c.Assert(net.Methods[0].Name, Equals, "connect")
+	c.Assert(net.Methods[1].Name, Equals, "disconnect")
+	c.Assert(net.Sections, HasLen, 1)
+
+	http := net.Sections[0]
+
+	c.Assert(http.Name, Equals, "HTTP")
+	c.Assert(http.Path, Equals, "Networking/HTTP")
+	c.Assert(http.Desc, IsNil)
+	c.Assert(http.Methods, HasLen, 1)
+	c.Assert(http.Methods[0].Name, Equals, "fetch")
+	c.Assert(http.Methods[0].Desc, DeepEquals, []string{"Fetch URL"})
+	c.Assert(http.Methods[0].Section, Equals, "Networking/HTTP")
+
+	c.Assert(doc.Sections[1].Name, Equals, "Strings")
+	c.Assert(doc.Sections[1].Methods[0].Name, Equals, "trim")
+
+	c.Assert(doc.FindSection("networking/http"), Equals, http)
+	c.Assert(doc.Groups(), HasLen, 3)
+}
+
+func (s *ParseSuite) TestLinks(c *C) {
+	doc, diags := readData("links.sh", strings.NewReader(_SCRIPT_LINKS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 3)
+
+	c.Assert(diags[0].Rule, Equals, RULE_UNKNOWN_REFERENCE)
+	c.Assert(diags[0].Message, Equals, "Reference to unknown entity unknown")
+	c.Assert(diags[0].Entity, Equals, "unknown")
+	c.Assert(diags[0].File, Equals, "links.sh")
+	c.Assert(diags[0].Line, Equals, 3)
+	c.Assert(diags[0].Column, Equals, 37)
+	c.Assert(diags[1].Entity, Equals, "missing_section")
+	c.Assert(diags[1].Line, Equals, 6)
+	c.Assert(diags[2].Entity, Equals, "${TARGET}")
+	c.Assert(diags[2].Line, Equals, 11)
+
+	m := doc.MarkupOf(doc.Methods[0].Desc)
+	spans := m.Spans()
+
+	c.Assert(spans, HasLen, 4)
+	c.Assert(spans[1].Ref, Equals, "BASE_DIR")
+	c.Assert(spans[1].Link, Equals, "9")
+	c.Assert(spans[3].Ref, Equals, "${TARGET}")
+	c.Assert(spans[3].Link, Equals, "")
+
+	spans = doc.MarkupOf(doc.Constants[0].Desc).Spans()
+
+	c.Assert(spans[1].Ref, Equals, "copy_file")
+	c.Assert(spans[1].Link, Equals, "17")
+}
+
+func (s *ParseSuite) TestAnnotatedDialect(c *C) {
+	c.Assert(detectDialect([]byte(_SCRIPT_ANNOTATED), shells[SHELL_BASH]).Name(), Equals, DIALECT_ANNOTATED)
+
+	doc, diags := readData("ann.sh", strings.NewReader(_SCRIPT_ANNOTATED))
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 3)
+	c.Assert(diags[0].Rule, Equals, RULE_MALFORMED_ARGUMENT)
+	c.Assert(diags[0].Line, Equals, 44)
+	c.Assert(diags[0].Column, Equals, 8)
+	c.Assert(diags[1].Rule, Equals, RULE_UNKNOWN_TAG)
+	c.Assert(diags[2].Rule, Equals, RULE_UNDOCUMENTED_ARGUMENT)
+
+	c.Assert(doc.About, DeepEquals, []string{"Library with helpers", "", "Helpers for greeting people."})
+	c.Assert(doc.Constants, HasLen, 1)
+	c.Assert(doc.Constants[0].Desc, DeepEquals, []string{"Default name", "  used for greetings"})
+	c.Assert(doc.Constants[0].Type, Equals, script.VAR_TYPE_STRING)
+	c.Assert(doc.Methods, HasLen, 2)
+
+	m := doc.Methods[0]
+
+	c.Assert(m.Name, Equals, "say_hello")
+	c.Assert(m.Desc, DeepEquals, []string{"Says hello to someone.", "  Second line of description."})
+	c.Assert(m.Arguments, HasLen, 3)
+	c.Assert(m.Arguments[0].Index, Equals, "1")
+	c.Assert(m.Arguments[0].Desc, Equals, "Name of person")
+	c.Assert(m.Arguments[0].Type, Equals, script.VAR_TYPE_STRING)
+	c.Assert(m.Arguments[1].Type, Equals, script.VAR_TYPE_NUMBER)
+	c.Assert(m.Arguments[1].Default, Equals, "1")
+	c.Assert(m.Arguments[2].Index, Equals, "*")
+	c.Assert(m.Arguments[2].Desc, Equals, "Extra words")
+	c.Assert(m.Arguments[2].Type, Equals, script.VAR_TYPE_UNKNOWN)
+	c.Assert(m.Modifies, HasLen, 1)
+	c.Assert(m.Modifies[0].Name, Equals, "GREETED")
+	c.Assert(m.Modifies[0].Desc, Equals, "Name of greeted person")
+	c.Assert(m.ResultCodes, DeepEquals, map[int]string{0: "If successful.", 1: "If an empty string passed.\nAlso if count is invalid."})
+	c.Assert(m.ResultEcho, NotNil)
+	c.Assert(m.ResultEcho.Desc, DeepEquals, []string{"Greeting text."})
+	c.Assert(m.Stderr, DeepEquals, []string{"Errors."})
+	c.Assert(m.Example, DeepEquals, []string{`say_hello "John" 2`, "  echo indented"})
+	c.Assert(m.See, DeepEquals, []string{"NAME"})
+	c.Assert(m.Deprecated, NotNil)
+	c.Assert(m.Deprecated.Replacement, Equals, "say_hi")
+
+	c.Assert(doc.Methods[1].Name, Equals, "bad_one")
+	c.Assert(doc.Methods[1].Arguments, HasLen, 0)
+
+	d := &annotatedDialect{}
+
+	c.Assert(d.ParseMethod("test", []string{"-"}), IsNil)
+	c.Assert(d.ParseVariable("test", "", []string{"@internal"}), IsNil)
+	c.Assert(d.ValidateMethod([]string{"@arg $0 string Test", "@arg $1 string", "@arg $1 string Test"}, []LinePos{{1, 3}, {2, 3}, {3, 3}}), HasLen, 3)
+
+	t, desc := cutAnnotatedType("url Link")
+	c.Assert(t, Equals, script.VAR_TYPE_URL)
+	c.Assert(desc, Equals, "Link")
+
+	t, desc = cutAnnotatedType("Some text")
+	c.Assert(t, Equals, script.VAR_TYPE_UNKNOWN)
+	c.Assert(desc, Equals, "Some text")
+
+	example, lines := getExampleLines("", []string{"", "  echo 1", "", "  echo 2", "Text"})
+	c.Assert(example, DeepEquals, []string{"", "echo 1", "", "echo 2"})
+	c.Assert(lines, Equals, 4)
+}
+
+func (s *ParseSuite) TestGoogleDialect(c *C) {
+	c.Assert(detectDialect([]byte(_SCRIPT_GOOGLE), shells[SHELL_BASH]).Name(), Equals, DIALECT_GOOGLE)
+
+	doc, diags := readData("google.sh", strings.NewReader(_SCRIPT_GOOGLE))
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 0)
+
+	c.Assert(doc.About, DeepEquals, []string{"Perform hot backups of Oracle databases."})
+	c.Assert(doc.Constants, HasLen, 1)
+	c.Assert(doc.Methods, HasLen, 2)
+
+	m := doc.Methods[0]
+
+	c.Assert(m.Desc, DeepEquals, []string{"Cleanup files from the backup directory."})
+	c.Assert(m.Reads, HasLen, 2)
+	c.Assert(m.Reads[0].Name, Equals, "BACKUP_DIR")
+	c.Assert(m.Reads[1].Name, Equals, "ORACLE_SID")
+	c.Assert(m.Reads[1].Desc, Equals, "SID of database")
+	c.Assert(m.Arguments, HasLen, 2)
+	c.Assert(m.Arguments[0].Index, Equals, "1")
+	c.Assert(m.Arguments[0].DescLines(), DeepEquals, []string{"Directory to clean", "with continuation"})
+	c.Assert(m.Arguments[1].Index, Equals, "2")
+	c.Assert(m.Arguments[1].Desc, Equals, "Pattern of files to remove")
+	c.Assert(m.ResultEcho.Desc, DeepEquals, []string{"Writes location to stdout"})
+	c.Assert(m.Stderr, DeepEquals, []string{"Writes errors to stderr"})
+	c.Assert(m.ResultCodes, DeepEquals, map[int]string{0: "if thing was deleted, non-zero on error."})
+
+	m = doc.Methods[1]
+
+	c.Assert(m.Reads, HasLen, 0)
+	c.Assert(m.Arguments, HasLen, 0)
+	c.Assert(m.ResultEcho, NotNil)
+
+	m = (&googleDialect{}).ParseMethod("test", []string{
+		"Test method",
+		"Arguments:",
+		"  $@ - All arguments",
+		"Returns:",
+		"  Nothing",
+		"Example:",
+		"  test 1 2",
+	})
+
+	c.Assert(m.Desc, DeepEquals, []string{"Test method", "", "Returns: Nothing"})
+	c.Assert(m.Arguments[0].Index, Equals, "*")
+	c.Assert(m.Example, DeepEquals, []string{"test 1 2"})
+	c.Assert((&googleDialect{}).ParseMethod("test", []string{"PRIVATE"}), IsNil)
+
+	items, lines := getGoogleItems("", []string{"  1 - ok", "", "    details", "  2 - error", "Text"})
+	c.Assert(items, DeepEquals, [][]string{{"1 - ok", "", "details"}, {"2 - error"}})
+	c.Assert(lines, Equals, 4)
+}
+
+func (s *ParseSuite) TestGoogleDiagnostics(c *C) {
+	doc, diags := readData("google.sh", strings.NewReader(_SCRIPT_GOOGLE_DIAGS))
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Methods[0].ResultCodes, DeepEquals, map[int]string{0: "on success", 1000: "weird"})
+
+	c.Assert(diags, HasLen, 5)
+	c.Assert(diags[0], DeepEquals, &Diagnostic{"google.sh", 6, 22, SEVERITY_WARNING, RULE_UNKNOWN_TYPE, `Unknown type marker "Strng"`, ""})
+	c.Assert(diags[1], DeepEquals, &Diagnostic{"google.sh", 7, 5, SEVERITY_ERROR, RULE_DUPLICATE_ARGUMENT, "Argument 1 is documented more than once", ""})
+	c.Assert(diags[2], DeepEquals, &Diagnostic{"google.sh", 8, 5, SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT, "Argument index must be greater than 0", ""})
+	c.Assert(diags[3], DeepEquals, &Diagnostic{"google.sh", 9, 5, SEVERITY_WARNING, RULE_MALFORMED_ARGUMENT, "Argument 2 doesn't have description", ""})
+	c.Assert(diags[4], DeepEquals, &Diagnostic{"google.sh", 12, 5, SEVERITY_WARNING, RULE_INVALID_CODE, "Exit code 1000 is out of range 0..255", ""})
+}
+
+func (s *ParseSuite) TestDialects(c *C) {
+	d, err := GetDialect("unknown")
+	c.Assert(err, ErrorMatches, `Unknown dialect "unknown"`)
+	c.Assert(d, IsNil)
+
+	d, err = GetDialect(DIALECT_AUTO)
+	c.Assert(err, IsNil)
+	c.Assert(d, IsNil)
+
+	d, err = GetDialect("")
+	c.Assert(err, IsNil)
+	c.Assert(d, IsNil)
+
+	d, err = GetDialect(DIALECT_GOOGLE)
+	c.Assert(err, IsNil)
+	c.Assert(d.Name(), Equals, DIALECT_GOOGLE)
+	c.Assert(d, Implements, new(Validator))
+
+	// Annotated comments are not parsed with forced dialect
+	doc, _, _, _ := readScript("annotated.sh", []byte(_SCRIPT_ANNOTATED), &Options{Dialect: d})
+	c.Assert(doc.Methods, HasLen, 1)
+	c.Assert(doc.Methods[0].Arguments, HasLen, 0)
+
+	c.Assert(detectDialect([]byte(_SCRIPT_ANNOTATED), shells[SHELL_BASH]).Name(), Equals, DIALECT_ANNOTATED)
+	c.Assert(detectDialect([]byte(_SCRIPT_PARAMS), shells[SHELL_BASH]).Name(), Equals, DIALECT_DEFAULT)
+	c.Assert(detectDialect([]byte(_SCRIPT_DIALECT_STRAY), shells[SHELL_BASH]).Name(), Equals, DIALECT_DEFAULT)
+
+	google := []string{"Read config option", "Returns:", "  Option value"}
+	annotated := []string{"@description Read config option"}
+	def := []string{"Read config option", "", "1: Name of option"}
+
+	c.Assert(detectBlocksDialect(nil).Name(), Equals, DIALECT_DEFAULT)
+	c.Assert(detectBlocksDialect([][]string{def, google, def}).Name(), Equals, DIALECT_DEFAULT)
+	c.Assert(detectBlocksDialect([][]string{google, def}).Name(), Equals, DIALECT_GOOGLE)
+	c.Assert(detectBlocksDialect([][]string{annotated, google, google}).Name(), Equals, DIALECT_GOOGLE)
+
+	doc, diags := readData("stray.sh", strings.NewReader(_SCRIPT_DIALECT_STRAY))
+
+	c.Assert(diags, HasLen, 0)
+	c.Assert(doc.Methods, HasLen, 1)
+	c.Assert(doc.Methods[0].Arguments, HasLen, 1)
+	c.Assert(doc.Methods[0].ResultEcho, NotNil)
+
+	// Dialect without validator
+	doc, _, _, diags = readScript("stray.sh", []byte(_SCRIPT_DIALECT_STRAY), &Options{Dialect: &plainDialect{}})
+
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0].Rule, Equals, RULE_UNDOCUMENTED_ARGUMENT)
+	c.Assert(doc.Methods, HasLen, 1)
+	c.Assert(doc.Methods[0].Desc, DeepEquals, []string{"plain"})
+	c.Assert(validateMethod(&plainDialect{}, []string{"test"}, nil), IsNil)
+	c.Assert(validateVariable(&plainDialect{}, []string{"test"}, nil), IsNil)
+}
+
+func (s *ParseSuite) TestZsh(c *C) {
+	doc, diags := readData("git.plugin.zsh", strings.NewReader(_SCRIPT_ZSH))
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Shell, Equals, SHELL_ZSH)
+	c.Assert(doc.About, DeepEquals, []string{"Plugin with helpers for git repositories"})
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0].Rule, Equals, RULE_UNDOCUMENTED_ARGUMENT)
+	c.Assert(diags[0].Line, Equals, 25)
+	c.Assert(diags[0].Column, Equals, 10)
+
+	c.Assert(doc.Variables, HasLen, 0)
+	c.Assert(doc.Constants, HasLen, 3)
+	c.Assert(doc.Constants[0].Name, Equals, "REMOTES")
+	c.Assert(doc.Constants[0].Type, Equals, script.VAR_TYPE_MAP)
+	c.Assert(doc.Constants[0].Elements, DeepEquals, []*script.Element{
+		{Key: "origin", Value: "github"}, {Key: "upstream", Value: "gitlab"},
+	})
+	c.Assert(doc.Constants[1].Name, Equals, "PRECISION")
+	c.Assert(doc.Constants[1].Type, Equals, script.VAR_TYPE_NUMBER)
+	c.Assert(doc.Constants[2].Name, Equals, "MAX_DEPTH")
+	c.Assert(doc.Constants[2].IsInteger, Equals, true)
+
+	c.Assert(doc.Methods, HasLen, 2)
+	c.Assert(doc.Methods[0].Name, Equals, "git:status")
+	c.Assert(doc.Methods[0].CalledBy, DeepEquals, []string{"+vi-segment"})
+	c.Assert(doc.Methods[1].Name, Equals, "+vi-segment")
+	c.Assert(doc.Methods[1].Line, Equals, 29)
+
+	doc, _ = readData("script.sh", strings.NewReader(
+		"#!/bin/bash\n\n# Precision\nfloat PRECISION=0.5\n\n# Functions\ntypeset -F FUNCS\n",
+	))
+
+	c.Assert(doc.Shell, Equals, SHELL_BASH)
+	c.Assert(doc.IsValid(), Equals, false)
+}
+
+func (s *ParseSuite) TestKsh(c *C) {
+	doc, diags := readData("tools", strings.NewReader(_SCRIPT_KSH))
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 0)
+	c.Assert(doc.Shell, Equals, SHELL_KSH)
+	c.Assert(doc.Constants, HasLen, 1)
+	c.Assert(doc.Constants[0].Type, Equals, script.VAR_TYPE_NUMBER)
+	c.Assert(doc.Methods, HasLen, 1)
+	c.Assert(doc.Methods[0].Name, Equals, "print_ratio")
+	c.Assert(doc.Methods[0].Reads, HasLen, 1)
+}
+
+func (s *ParseSuite) TestShellDetection(c *C) {
+	c.Assert(detectShell("script", []byte("#!/bin/bash\n")).Name, Equals, SHELL_BASH)
+	c.Assert(detectShell("script", []byte("#!/bin/sh\n")).Name, Equals, SHELL_SH)
+	c.Assert(detectShell("script", []byte("#!/usr/bin/dash\n")).Name, Equals, SHELL_SH)
+	c.Assert(detectShell("script.sh", []byte("#!/usr/bin/env -S zsh -f\n")).Name, Equals, SHELL_ZSH)
+	c.Assert(detectShell("script.zsh", []byte("#!/bin/ksh93\n")).Name, Equals, SHELL_KSH)
+	c.Assert(detectShell("script", []byte("#compdef git\n")).Name, Equals, SHELL_ZSH)
+	c.Assert(detectShell("theme.zsh-theme", []byte("echo 1")).Name, Equals, SHELL_ZSH)
+	c.Assert(detectShell("script.ksh", []byte("#!/usr/bin/python\n")).Name, Equals, SHELL_KSH)
+	c.Assert(detectShell("script.sh", nil).Name, Equals, SHELL_BASH)
+	c.Assert(detectShell("script", []byte("echo 1")).Name, Equals, SHELL_BASH)
+
+	c.Assert(pairElements(nil), IsNil)
+	c.Assert(pairElements([]*script.Element{{Value: "a"}}), DeepEquals, []*script.Element{{Key: "a"}})
+	c.Assert(pairElements([]*script.Element{{Key: "a", Value: "1"}, {Value: "b"}}), HasLen, 2)
+
+	index, ok := parseArgvRef("$argv", 0)
+	c.Assert(index, Equals, 0)
+	c.Assert(ok, Equals, true)
+	index, ok = parseArgvRef("${argv[3]}", 0)
+	c.Assert(index, Equals, 3)
+	c.Assert(ok, Equals, true)
+	_, ok = parseArgvRef("$argv[0]", 0)
+	c.Assert(ok, Equals, false)
+	_, ok = parseArgvRef("$argvs", 0)
+	c.Assert(ok, Equals, false)
+}
+
+func (s *ParseSuite) TestAutoload(c *C) {
+	doc, diags := ParseAutoload(s.TmpDir+"/functions", nil)
+
+	c.Assert(doc, NotNil)
+	c.Assert(doc.Title, Equals, "functions")
+	c.Assert(doc.Shell, Equals, SHELL_ZSH)
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0].Rule, Equals, RULE_MISSING_DOCS)
+	c.Assert(diags[0].File, Equals, s.TmpDir+"/functions/undocumented")
+	c.Assert(diags[0].Entity, Equals, "undocumented")
+
+	c.Assert(doc.Methods, HasLen, 2)
+
+	greet, join := doc.Methods[0], doc.Methods[1]
+
+	c.Assert(greet.Name, Equals, "greet")
+	c.Assert(greet.Line, Equals, 1)
+	c.Assert(greet.File, Equals, s.TmpDir+"/functions/greet")
+	c.Assert(greet.Desc, DeepEquals, []string{"Greets user"})
+	c.Assert(greet.Arguments, HasLen, 1)
+	c.Assert(greet.Arguments[0].Type, Equals, script.VAR_TYPE_STRING)
+	c.Assert(greet.CalledBy, DeepEquals, []string{"join"})
+	c.Assert(join.Name, Equals, "join")
+	c.Assert(join.Arguments, HasLen, 2)
+	c.Assert(join.Calls, DeepEquals, []string{"greet"})
+
+	doc, diags = Parse(s.TmpDir+"/functions/greet", nil)
+
+	c.Assert(doc, NotNil)
+	c.Assert(diags, HasLen, 0)
+	c.Assert(doc.Methods, HasLen, 1)
+	c.Assert(doc.Methods[0].Name, Equals, "greet")
+
+	doc, diags = ParseAutoload(s.TmpDir+"/unknown", nil)
+
+	c.Assert(doc, IsNil)
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0].Rule, Equals, RULE_OPEN_ERROR)
+
+	c.Assert(getAutoloadName("/functions/prompt_setup"), Equals, "prompt_setup")
+	c.Assert(getAutoloadName("/functions/join.zsh"), Equals, "join")
+	c.Assert(getAutoloadName("/functions/git.plugin"), Equals, "git.plugin")
+}
+
+func (s *ParseSuite) TestSet(c *C) {
+	set, diags := ParseSet(s.TmpDir+"/set/main.sh", nil)
+
+	c.Assert(set, NotNil)
+	c.Assert(set.Documents, HasLen, 2)
+	c.Assert(diags, HasLen, 2)
+
+	c.Assert(diags[0].Rule, Equals, RULE_MISSING_SOURCE)
+	c.Assert(diags[0].Line, Equals, 4)
+	c.Assert(diags[1].Rule, Equals, RULE_DYNAMIC_SOURCE)
+	c.Assert(diags[1].Line, Equals, 5)
+	c.Assert(diags[1].Severity, Equals, SEVERITY_NOTICE)
+
+	main, lib := set.Documents[0], set.Documents[1]
+
+	c.Assert(set.Root(), Equals, main)
+	c.Assert(main.File, Equals, s.TmpDir+"/set/main.sh")
+	c.Assert(lib.File, Equals, s.TmpDir+"/set/lib/net.sh")
+	c.Assert(main.Includes, HasLen, 3)
+	c.Assert(main.Includes[0].Path, Equals, `"$(dirname "$0")/lib/net.sh"`)
+	c.Assert(main.Includes[0].File, Equals, lib.File)
+	c.Assert(main.Includes[2].File, Equals, "")
+	c.Assert(lib.Includes, HasLen, 1)
+	c.Assert(lib.Includes[0].File, Equals, main.File)
+
+	c.Assert(main.Methods[0].File, Equals, main.File)
+	c.Assert(lib.Methods[0].File, Equals, lib.File)
+	c.Assert(main.Methods[0].Calls, DeepEquals, []string{"download"})
+	c.Assert(lib.Methods[0].CalledBy, DeepEquals, []string{"main"})
+
+	set, diags = ParseSet(s.TmpDir+"/set/unknown.sh", nil)
+
+	c.Assert(set.Root(), IsNil)
+	c.Assert(diags, HasLen, 1)
+	c.Assert(diags[0].Rule, Equals, RULE_OPEN_ERROR)
+
+	c.Assert(parseSources(`source lib.sh`), DeepEquals, []string{"lib.sh"})
+	c.Assert(parseSources(`[[ -f a.sh ]] && . "a b.sh"; source c.sh # source d.sh`), DeepEquals, []string{`"a b.sh"`, "c.sh"})
+	c.Assert(parseSources(`if true ; then source x.sh ; fi`), DeepEquals, []string{"x.sh"})
+	c.Assert(parseSources(`resource x.sh`), HasLen, 0)
+
+	c.Assert(resolveSource("lib.sh", "/opt"), Equals, "/opt/lib.sh")
+	c.Assert(resolveSource("/etc/lib.sh", "/opt"), Equals, "/etc/lib.sh")
+	c.Assert(resolveSource(`"$(dirname "${BASH_SOURCE[0]}")/../lib.sh"`, "/opt/bin"), Equals, "/opt/lib.sh")
+	c.Assert(resolveSource("`dirname $0`/lib.sh", "/opt"), Equals, "/opt/lib.sh")
+	c.Assert(resolveSource(`"${0%/*}/lib.sh"`, "lib"), Equals, "lib/lib.sh")
+	c.Assert(resolveSource(`"$DIR/lib.sh"`, "/opt"), Equals, "")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// skipRules returns diagnostics without diagnostics with given rules
+func skipRules(diags Diagnostics, rules ...string) Diagnostics {
+	var result Diagnostics
+
+	for _, d := range diags {
+		if !slices.Contains(rules, d.Rule) {
+			result = append(result, d)
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// plainDialect is dialect without validation which uses the whole comment as
+// description
+type plainDialect struct{}
+
+func (d *plainDialect) Name() string                      { return "plain" }
+func (d *plainDialect) Detect(data []string) bool         { return true }
+func (d *plainDialect) ParseAbout(data []string) []string { return nil }
+
+func (d *plainDialect) ParseMethod(name string, data []string) *script.Method {
+	return &script.Method{Name: name, Desc: []string{"plain"}}
+}
+
+func (d *plainDialect) ParseVariable(name, value string, data []string) *script.Variable {
+	return nil
+}
@@ -0,0 +1,97 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var sectionRegExp = regexp.MustCompile(`^@section[ \t]+([^/\s].*)$`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isSectionMarker returns true if comment starts with section marker
+func isSectionMarker(data []string) bool {
+	return len(data) != 0 && sectionRegExp.MatchString(strings.TrimRight(data[0], " "))
+}
+
+// readSection reads section marker with description (all lines before the
+// first empty line) from comment, adds section to document and returns it
+// with the rest of the comment
+func readSection(doc *script.Document, data []string, pos []LinePos) (*script.Section, []string, []LinePos) {
+	path := sectionRegExp.FindStringSubmatch(strings.TrimRight(data[0], " "))[1]
+	section := addSection(doc, path)
+
+	end := len(data)
+
+	for index, line := range data {
+		if line == "" {
+			end = index
+			break
+		}
+	}
+
+	if section.Line == 0 {
+		section.Line, section.File = pos[0].Line, doc.File
+	}
+
+	if section.Desc == nil && end > 1 {
+		section.Desc = getCleanData(data[1:end])
+	}
+
+	if end >= len(data)-1 {
+		return section, nil, nil
+	}
+
+	return section, data[end+1:], pos[end+1:]
+}
+
+// addSection adds section with given path (Parent/Name) to document sections
+// tree and returns it, already existing sections are reused
+func addSection(doc *script.Document, path string) *script.Section {
+	var section *script.Section
+	var fullPath []string
+
+	sections := &doc.Sections
+
+	for _, name := range strings.Split(path, "/") {
+		name = strings.TrimSpace(name)
+
+		if name == "" {
+			continue
+		}
+
+		fullPath = append(fullPath, name)
+		section = findChildSection(*sections, name)
+
+		if section == nil {
+			section = &script.Section{Name: name, Path: strings.Join(fullPath, "/")}
+			*sections = append(*sections, section)
+		}
+
+		sections = &section.Sections
+	}
+
+	return section
+}
+
+// findChildSection returns section with given name from slice
+func findChildSection(sections []*script.Section, name string) *script.Section {
+	for _, s := range sections {
+		if s.Name == name {
+			return s
+		}
+	}
+
+	return nil
+}
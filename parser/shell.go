@@ -0,0 +1,184 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Supported shells
+const (
+	SHELL_SH   = "sh"
+	SHELL_BASH = "bash"
+	SHELL_ZSH  = "zsh"
+	SHELL_KSH  = "ksh"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// shell contains parsing rules specific for shell
+type shell struct {
+	Name         string         // Name of shell
+	MethodRegExp *regexp.Regexp // Method definition (name() …)
+	FuncRegExp   *regexp.Regexp // Method definition with "function" keyword
+	AnonRegExp   *regexp.Regexp // Anonymous function definition (nil if not supported)
+	HeaderRegExp *regexp.Regexp // Commands allowed in script header (nil if not supported)
+	HasNumbers   bool           // Shell has integer and float builtins, typeset -F/-E declares float
+	HasPairs     bool           // Associative array can be defined as list of key-value pairs
+	HasArgv      bool           // Positional parameters are available as $argv array
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	shellShebangRegExp = regexp.MustCompile(`^#![ \t]*[^ \t]*/(?:env[ \t]+(?:-[^ \t]+[ \t]+)*)?([a-z0-9]+)(?:[ \t]|$)`)
+	autoloadRegExp     = regexp.MustCompile(`^#(compdef|autoload)(?:[ \t]|$)`)
+
+	zshMethodRegExp = regexp.MustCompile(`^([a-zA-Z0-9._:+@-]{1,})[ \t]*\([ \t]*\)`)
+	zshFuncRegExp   = regexp.MustCompile(`^function[ \t]+([a-zA-Z0-9._:+@-]{1,})[ \t]*(\([ \t]*\))?[ \t]*(\{.*|#.*)?$`)
+	zshAnonRegExp   = regexp.MustCompile(`^(?:function[ \t]*|(?:function[ \t]*)?\([ \t]*\)[ \t]*)\{`)
+	zshHeaderRegExp = regexp.MustCompile(`^(?:emulate|setopt|unsetopt|zmodload|autoload)(?:[ \t]|$)`)
+	argvRegExp      = regexp.MustCompile(`^\$\{?argv(?:\[([0-9]{1,}|@|\*)\])?(?:[^a-zA-Z0-9_\[]|$)`)
+)
+
+// shells contains rules for all supported shells
+var shells = map[string]*shell{
+	SHELL_SH: {
+		Name:         SHELL_SH,
+		MethodRegExp: methodRegExp,
+		FuncRegExp:   funcRegExp,
+	},
+	SHELL_BASH: {
+		Name:         SHELL_BASH,
+		MethodRegExp: methodRegExp,
+		FuncRegExp:   funcRegExp,
+	},
+	SHELL_ZSH: {
+		Name:         SHELL_ZSH,
+		MethodRegExp: zshMethodRegExp,
+		FuncRegExp:   zshFuncRegExp,
+		AnonRegExp:   zshAnonRegExp,
+		HeaderRegExp: zshHeaderRegExp,
+		HasNumbers:   true,
+		HasPairs:     true,
+		HasArgv:      true,
+	},
+	SHELL_KSH: {
+		Name:         SHELL_KSH,
+		MethodRegExp: methodRegExp,
+		FuncRegExp:   funcRegExp,
+		HasNumbers:   true,
+	},
+}
+
+// shellAliases contains names of shell interpreters compatible with
+// supported shells
+var shellAliases = map[string]string{
+	"dash":  SHELL_SH,
+	"ash":   SHELL_SH,
+	"mksh":  SHELL_KSH,
+	"pdksh": SHELL_KSH,
+	"ksh93": SHELL_KSH,
+}
+
+// shellExtensions contains extensions of scripts for supported shells
+var shellExtensions = map[string]string{
+	".bash":      SHELL_BASH,
+	".zsh":       SHELL_ZSH,
+	".zsh-theme": SHELL_ZSH,
+	".ksh":       SHELL_KSH,
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// detectShell returns rules for shell used by script with given name and data,
+// shell is detected by shebang or file extension (bash is used by default)
+func detectShell(file string, data []byte) *shell {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	line = bytes.TrimRight(line, "\r")
+
+	if autoloadRegExp.Match(line) {
+		return shells[SHELL_ZSH]
+	}
+
+	if m := shellShebangRegExp.FindSubmatch(line); m != nil {
+		name := string(m[1])
+
+		if alias, ok := shellAliases[name]; ok {
+			name = alias
+		}
+
+		if shells[name] != nil {
+			return shells[name]
+		}
+	}
+
+	if name, ok := shellExtensions[filepath.Ext(file)]; ok {
+		return shells[name]
+	}
+
+	return shells[SHELL_BASH]
+}
+
+// isAutoloadFile returns true if data is a body of autoloaded zsh function
+// (#autoload or #compdef header)
+func isAutoloadFile(data []byte) bool {
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	return autoloadRegExp.Match(bytes.TrimRight(line, "\r"))
+}
+
+// parseArgvRef parses reference to zsh positional parameters array ($argv,
+// $argv[1], ${argv[@]}) and returns parameter index (0 for whole array)
+func parseArgvRef(line string, i int) (int, bool) {
+	m := argvRegExp.FindStringSubmatch(line[i:])
+
+	if m == nil {
+		return 0, false
+	}
+
+	switch m[1] {
+	case "", "@", "*":
+		return 0, true
+	}
+
+	index, err := strconv.Atoi(m[1])
+
+	return index, err == nil && index > 0
+}
+
+// pairElements converts elements of associative array defined as list of
+// key-value pairs (m=(key1 value1 key2 value2)) to elements with keys
+func pairElements(elements []*script.Element) []*script.Element {
+	var result []*script.Element
+
+	// Array defined with explicit keys ([key]=value)
+	for _, e := range elements {
+		if e.Key != "" {
+			return elements
+		}
+	}
+
+	for i := 0; i < len(elements); i += 2 {
+		e := &script.Element{Key: elements[i].Value}
+
+		if i+1 < len(elements) {
+			e.Value = elements[i+1].Value
+		}
+
+		result = append(result, e)
+	}
+
+	return result
+}
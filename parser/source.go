@@ -0,0 +1,150 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	sourceRegExp  = regexp.MustCompile(`(?:^|[;&|{(][ \t]*|\b(?:then|do|else)[ \t]+)(?:source|\.)[ \t]+`)
+	dirnameRegExp = regexp.MustCompile(`\$\([ \t]*dirname[ \t]+"?(?:\$0|\$\{0\}|\$BASH_SOURCE|\$\{BASH_SOURCE(?:\[0\])?\})"?[ \t]*\)|` +
+		"`" + `dirname[ \t]+"?(?:\$0|\$\{0\}|\$BASH_SOURCE|\$\{BASH_SOURCE(?:\[0\])?\})"?` + "`" +
+		`|\$\{(?:0|BASH_SOURCE(?:\[0\])?)%/\*\}|\$\{0(?::A)?:h\}`)
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ParseSet parses script and all scripts sourced by it
+func ParseSet(file string, opts *Options) (*script.DocumentSet, Diagnostics) {
+	var diags Diagnostics
+	var bodies []*methodBody
+	var links []*link
+
+	set := &script.DocumentSet{}
+	queue := []string{filepath.Clean(file)}
+	visited := map[string]bool{}
+
+	for len(queue) != 0 {
+		file, queue = queue[0], queue[1:]
+
+		if visited[file] {
+			continue
+		}
+
+		visited[file] = true
+
+		doc, fileBodies, fileLinks, fileDiags := parseFile(file, opts)
+
+		diags = append(diags, fileDiags...)
+
+		if doc == nil {
+			continue
+		}
+
+		set.Documents = append(set.Documents, doc)
+		bodies = append(bodies, fileBodies...)
+		links = append(links, fileLinks...)
+
+		for _, inc := range doc.Includes {
+			switch {
+			case inc.File == "":
+				diags = append(diags, newSourceDiagnostic(
+					file, inc, SEVERITY_NOTICE, RULE_DYNAMIC_SOURCE,
+					fmt.Sprintf("Can't resolve path to sourced script %s", inc.Path),
+				))
+			case visited[inc.File]:
+				continue
+			case !fsutil.CheckPerms("FRS", inc.File):
+				diags = append(diags, newSourceDiagnostic(
+					file, inc, SEVERITY_WARNING, RULE_MISSING_SOURCE,
+					fmt.Sprintf("Sourced script %s doesn't exist or isn't readable", inc.File),
+				))
+			default:
+				queue = append(queue, inc.File)
+			}
+		}
+	}
+
+	if len(set.Documents) != 0 {
+		doc := set.Merge()
+
+		linkBodies(doc, bodies)
+		diags = append(diags, checkLinks(doc, links)...)
+	}
+
+	return set, diags
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseSources extracts paths of sourced scripts from line of code
+func parseSources(line string) []string {
+	var result []string
+
+	line = stripComment(line)
+
+	for _, loc := range sourceRegExp.FindAllStringIndex(line, -1) {
+		path := line[loc[1]:]
+		end := (&lexer{}).ScanValue(path, false)
+
+		if end != -1 {
+			path = path[:end]
+		}
+
+		if path != "" {
+			result = append(result, path)
+		}
+	}
+
+	return result
+}
+
+// resolveSource resolves path to sourced script relative to directory
+// with script
+func resolveSource(path, dir string) string {
+	path = dirnameRegExp.ReplaceAllLiteralString(path, "\x00")
+
+	// Remove quotes
+	path = strings.NewReplacer(`"`, "", `'`, "").Replace(path)
+
+	if strings.ContainsAny(path, "$`*?") {
+		return ""
+	}
+
+	if strings.Contains(path, "\x00") {
+		return filepath.Clean(strings.ReplaceAll(path, "\x00", dir))
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	return filepath.Clean(path)
+}
+
+// newSourceDiagnostic creates new diagnostic for source command
+func newSourceDiagnostic(file string, inc *script.Include, severity Severity, rule, message string) *Diagnostic {
+	return &Diagnostic{
+		File:     file,
+		Line:     inc.Line,
+		Column:   1,
+		Severity: severity,
+		Rule:     rule,
+		Message:  message,
+	}
+}
@@ -0,0 +1,131 @@
+package parser
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Supported tags
+const (
+	TAG_DEPRECATED = "deprecated"
+	TAG_SINCE      = "since"
+	TAG_SEE        = "see"
+	TAG_AUTHOR     = "author"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	tagRegExp         = regexp.MustCompile(`^@([a-zA-Z]{1,})(?:[ \t]+(.*))?$`)
+	replacementRegExp = regexp.MustCompile(`^([a-zA-Z0-9._:]{1,})(?:\(\))?$`)
+)
+
+// knownTags is a slice with all supported tags
+var knownTags = []string{TAG_DEPRECATED, TAG_SINCE, TAG_SEE, TAG_AUTHOR}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseTags extracts tags from comment data and returns data without tag lines
+// and parsed tags
+func parseTags(data []string) ([]string, script.Tags) {
+	var result []string
+	var tags script.Tags
+
+	for index, line := range data {
+		if strings.HasPrefix(line, "Example:") {
+			result = append(result, data[index:]...)
+			break // Example is last part of comment
+		}
+
+		if !isTagLine(line) {
+			result = append(result, line)
+			continue
+		}
+
+		td := tagRegExp.FindStringSubmatch(strings.TrimRight(line, " "))
+		value := strings.TrimSpace(td[2])
+
+		switch strings.ToLower(td[1]) {
+		case TAG_DEPRECATED:
+			tags.Deprecated = parseDeprecation(value)
+		case TAG_SINCE:
+			tags.Since = value
+		case TAG_SEE:
+			if value != "" {
+				tags.See = append(tags.See, value)
+			}
+		case TAG_AUTHOR:
+			switch {
+			case value == "":
+				continue
+			case tags.Author == "":
+				tags.Author = value
+			default:
+				tags.Author += ", " + value
+			}
+		}
+	}
+
+	return result, tags
+}
+
+// parseDeprecation parses value of @deprecated tag
+func parseDeprecation(value string) *script.Deprecation {
+	if replacementRegExp.MatchString(value) {
+		return &script.Deprecation{
+			Replacement: replacementRegExp.FindStringSubmatch(value)[1],
+		}
+	}
+
+	return &script.Deprecation{Note: value}
+}
+
+// isTagLine returns true if given comment line contains tag
+func isTagLine(line string) bool {
+	return tagRegExp.MatchString(strings.TrimRight(line, " "))
+}
+
+// validateTags checks tags in comment and returns slice with diagnostics
+func validateTags(data []string, pos []LinePos) Diagnostics {
+	return checkTags(data, pos, knownTags)
+}
+
+// checkTags checks that comment contains only tags from given slice and
+// returns slice with diagnostics
+func checkTags(data []string, pos []LinePos, known []string) Diagnostics {
+	var result Diagnostics
+
+	for index, line := range data {
+		if strings.HasPrefix(line, "Example:") {
+			break // Example is last part of comment
+		}
+
+		if !isTagLine(line) {
+			continue
+		}
+
+		tag := tagRegExp.FindStringSubmatch(strings.TrimRight(line, " "))[1]
+
+		if !slices.Contains(known, strings.ToLower(tag)) {
+			result = append(result, newDiagnostic(
+				pos[index], 0, SEVERITY_WARNING, RULE_UNKNOWN_TAG,
+				fmt.Sprintf("Unknown tag @%s", tag),
+			))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,227 @@
+package graph
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/shdoc/script"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const (
+	FORMAT_DOT     = "dot"
+	FORMAT_MERMAID = "mermaid"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Render renders methods call graph in given format to output file (or
+// stdout if output is empty)
+func Render(doc *script.Document, format, output string) error {
+	return write(output, func(w io.Writer) error {
+		switch format {
+		case FORMAT_DOT:
+			renderDOT(w, doc)
+		case FORMAT_MERMAID:
+			renderMermaid(w, doc)
+		default:
+			return fmt.Errorf("Unsupported graph format %q", format)
+		}
+
+		return nil
+	})
+}
+
+// RenderIncludes renders graph of sourced scripts in given format to output
+// file (or stdout if output is empty)
+func RenderIncludes(set *script.DocumentSet, format, output string) error {
+	return write(output, func(w io.Writer) error {
+		switch format {
+		case FORMAT_DOT:
+			renderIncludesDOT(w, set)
+		case FORMAT_MERMAID:
+			renderIncludesMermaid(w, set)
+		default:
+			return fmt.Errorf("Unsupported graph format %q", format)
+		}
+
+		return nil
+	})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// write writes data to output file (or stdout if output is empty)
+func write(output string, render func(w io.Writer) error) error {
+	var w io.Writer = os.Stdout
+
+	if output != "" {
+		fd, err := os.OpenFile(output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+
+		if err != nil {
+			return err
+		}
+
+		defer fd.Close()
+
+		w = fd
+	}
+
+	bw := bufio.NewWriter(w)
+	err := render(bw)
+
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderDOT renders graph in Graphviz DOT format
+func renderDOT(w io.Writer, doc *script.Document) {
+	fmt.Fprintf(w, "digraph %s {\n", quoteDOT(doc.Title))
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box, fontname=\"monospace\"];")
+
+	if len(doc.Methods) != 0 {
+		fmt.Fprintln(w)
+	}
+
+	for _, m := range doc.Methods {
+		fmt.Fprintf(w, "  %s;\n", quoteDOT(m.Name))
+	}
+
+	if hasCalls(doc) {
+		fmt.Fprintln(w)
+	}
+
+	for _, m := range doc.Methods {
+		for _, c := range m.Calls {
+			fmt.Fprintf(w, "  %s -> %s;\n", quoteDOT(m.Name), quoteDOT(c))
+		}
+	}
+
+	fmt.Fprintln(w, "}")
+}
+
+// renderMermaid renders graph in Mermaid flowchart format
+func renderMermaid(w io.Writer, doc *script.Document) {
+	ids := make(map[string]string, len(doc.Methods))
+
+	fmt.Fprintln(w, "graph LR")
+
+	for i, m := range doc.Methods {
+		ids[m.Name] = fmt.Sprintf("m%d", i+1)
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", ids[m.Name], strings.ReplaceAll(m.Name, "\"", "#quot;"))
+	}
+
+	for _, m := range doc.Methods {
+		for _, c := range m.Calls {
+			fmt.Fprintf(w, "  %s --> %s\n", ids[m.Name], ids[c])
+		}
+	}
+}
+
+// renderIncludesDOT renders graph of sourced scripts in Graphviz DOT format
+func renderIncludesDOT(w io.Writer, set *script.DocumentSet) {
+	root := set.Root()
+
+	fmt.Fprintf(w, "digraph %s {\n", quoteDOT(root.Title))
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=note, fontname=\"monospace\"];")
+	fmt.Fprintln(w)
+
+	for _, d := range set.Documents {
+		fmt.Fprintf(w, "  %s;\n", quoteDOT(getScriptName(root, d.File)))
+	}
+
+	edges := getIncludes(set)
+
+	if len(edges) != 0 {
+		fmt.Fprintln(w)
+	}
+
+	for _, e := range edges {
+		fmt.Fprintf(w, "  %s -> %s;\n", quoteDOT(getScriptName(root, e[0])), quoteDOT(getScriptName(root, e[1])))
+	}
+
+	fmt.Fprintln(w, "}")
+}
+
+// renderIncludesMermaid renders graph of sourced scripts in Mermaid flowchart
+// format
+func renderIncludesMermaid(w io.Writer, set *script.DocumentSet) {
+	root := set.Root()
+	ids := make(map[string]string, len(set.Documents))
+
+	fmt.Fprintln(w, "graph LR")
+
+	for i, d := range set.Documents {
+		ids[d.File] = fmt.Sprintf("s%d", i+1)
+		fmt.Fprintf(w, "  %s[\"%s\"]\n", ids[d.File], strings.ReplaceAll(getScriptName(root, d.File), "\"", "#quot;"))
+	}
+
+	for _, e := range getIncludes(set) {
+		fmt.Fprintf(w, "  %s --> %s\n", ids[e[0]], ids[e[1]])
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getIncludes returns unique pairs of including and included scripts
+func getIncludes(set *script.DocumentSet) [][2]string {
+	var result [][2]string
+
+	for _, d := range set.Documents {
+		for _, inc := range d.Includes {
+			edge := [2]string{d.File, inc.File}
+
+			if set.Find(inc.File) == nil || slices.Contains(result, edge) {
+				continue
+			}
+
+			result = append(result, edge)
+		}
+	}
+
+	return result
+}
+
+// getScriptName returns name of script relative to the root script
+func getScriptName(root *script.Document, file string) string {
+	if file == root.File {
+		return root.Title
+	}
+
+	return root.OriginOf(file)
+}
+
+// hasCalls returns true if at least one method calls another one
+func hasCalls(doc *script.Document) bool {
+	for _, m := range doc.Methods {
+		if m.HasCalls() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// quoteDOT returns quoted DOT identifier
+func quoteDOT(id string) string {
+	return "\"" + strings.ReplaceAll(id, "\"", "\\\"") + "\""
+}
@@ -0,0 +1,135 @@
+package graph
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"testing"
+
+	"github.com/essentialkaos/shdoc/script"
+
+	. "github.com/essentialkaos/check"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+const _CALLS_DOT = `digraph "git.plugin.zsh" {
+  rankdir=LR;
+  node [shape=box, fontname="monospace"];
+
+  "+vi-segment";
+  "git:status";
+  "say\"hi";
+
+  "+vi-segment" -> "git:status";
+  "git:status" -> "say\"hi";
+}
+`
+
+const _CALLS_MERMAID = `graph LR
+  m1["+vi-segment"]
+  m2["git:status"]
+  m3["say#quot;hi"]
+  m1 --> m2
+  m2 --> m3
+`
+
+const _INCLUDES_DOT = `digraph "main.sh" {
+  rankdir=LR;
+  node [shape=note, fontname="monospace"];
+
+  "main.sh";
+  "lib/git:utils.sh";
+
+  "main.sh" -> "lib/git:utils.sh";
+}
+`
+
+const _INCLUDES_MERMAID = `graph LR
+  s1["main.sh"]
+  s2["lib/git:utils.sh"]
+  s1 --> s2
+`
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func Test(t *testing.T) { TestingT(t) }
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+type GraphSuite struct {
+	TmpDir string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var _ = Suite(&GraphSuite{})
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func (s *GraphSuite) SetUpSuite(c *C) {
+	s.TmpDir = c.MkDir()
+}
+
+func (s *GraphSuite) TestCalls(c *C) {
+	doc := &script.Document{
+		Title: "git.plugin.zsh",
+		Methods: []*script.Method{
+			{Name: "+vi-segment", Calls: []string{"git:status"}},
+			{Name: "git:status", Calls: []string{`say"hi`}},
+			{Name: `say"hi`},
+		},
+	}
+
+	c.Assert(Render(doc, FORMAT_DOT, s.TmpDir+"/calls.dot"), IsNil)
+	c.Assert(readFile(c, s.TmpDir+"/calls.dot"), Equals, _CALLS_DOT)
+
+	c.Assert(Render(doc, FORMAT_MERMAID, s.TmpDir+"/calls.mmd"), IsNil)
+	c.Assert(readFile(c, s.TmpDir+"/calls.mmd"), Equals, _CALLS_MERMAID)
+
+	c.Assert(Render(doc, "svg", s.TmpDir+"/calls.svg"), ErrorMatches, `Unsupported graph format "svg"`)
+	c.Assert(Render(doc, FORMAT_DOT, s.TmpDir+"/unknown/calls.dot"), NotNil)
+}
+
+func (s *GraphSuite) TestIncludes(c *C) {
+	set := &script.DocumentSet{
+		Documents: []*script.Document{
+			{
+				Title: "main.sh",
+				File:  "/scripts/main.sh",
+				Includes: []*script.Include{
+					{Path: "lib/git:utils.sh", File: "/scripts/lib/git:utils.sh"},
+					{Path: "lib/git:utils.sh", File: "/scripts/lib/git:utils.sh"},
+					{Path: "missing.sh", File: "/scripts/missing.sh"},
+				},
+			},
+			{Title: "git:utils.sh", File: "/scripts/lib/git:utils.sh"},
+		},
+	}
+
+	c.Assert(RenderIncludes(set, FORMAT_DOT, s.TmpDir+"/includes.dot"), IsNil)
+	c.Assert(readFile(c, s.TmpDir+"/includes.dot"), Equals, _INCLUDES_DOT)
+
+	c.Assert(RenderIncludes(set, FORMAT_MERMAID, s.TmpDir+"/includes.mmd"), IsNil)
+	c.Assert(readFile(c, s.TmpDir+"/includes.mmd"), Equals, _INCLUDES_MERMAID)
+
+	c.Assert(RenderIncludes(set, "svg", s.TmpDir+"/includes.svg"), ErrorMatches, `Unsupported graph format "svg"`)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readFile returns data from file with given path
+func readFile(c *C, file string) string {
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		c.Fatal(err.Error())
+	}
+
+	return string(data)
+}
@@ -10,6 +10,8 @@ package template
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"text/template"
 
 	"github.com/essentialkaos/ek/v13/fmtc"
@@ -22,7 +24,7 @@ import (
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-// Render prints script info into terminal
+// Render renders script documentation with given template to output file
 func Render(doc *script.Document, tmpl, output string) error {
 	templateFile := getPathToTemplate(tmpl)
 
@@ -36,21 +38,71 @@ func Render(doc *script.Document, tmpl, output string) error {
 		return err
 	}
 
-	fd, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY, 0644)
+	err = renderFile(t, doc, output)
 
 	if err != nil {
 		return err
 	}
 
-	defer fd.Close()
+	printDocumentStats(doc, output)
 
-	err = t.Execute(fd, doc)
+	return nil
+}
+
+// RenderIndex renders documentation for every script in index and index page
+// with links to all of them to output directory
+func RenderIndex(index *script.Index, tmpl, outputDir string) error {
+	templateFile := getPathToTemplate(tmpl)
+
+	if templateFile == "" {
+		return fmt.Errorf("Can't find template %q", tmpl)
+	}
+
+	indexTemplateFile := getPathToIndexTemplate(tmpl, templateFile)
+
+	if indexTemplateFile == "" {
+		return fmt.Errorf("Can't find index template for template %q", tmpl)
+	}
+
+	t, err := readTemplate(templateFile)
 
 	if err != nil {
 		return err
 	}
 
-	printDocumentStats(doc, output)
+	it, err := readTemplate(indexTemplateFile)
+
+	if err != nil {
+		return err
+	}
+
+	ext := getOutputExt(tmpl)
+
+	for _, e := range index.Scripts {
+		e.Link = e.Path + ext
+		output := filepath.Join(outputDir, e.Link)
+
+		err = os.MkdirAll(filepath.Dir(output), 0755)
+
+		if err != nil {
+			return err
+		}
+
+		err = renderFile(t, e.Document, output)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	indexFile := filepath.Join(outputDir, "index"+ext)
+	err = renderFile(it, index, indexFile)
+
+	if err != nil {
+		return err
+	}
+
+	printIndexStats(index, indexFile)
 
 	return nil
 }
@@ -76,6 +128,56 @@ func getPathToTemplate(tmpl string) string {
 	return ""
 }
 
+// getPathToIndexTemplate returns path to index template for given template
+func getPathToIndexTemplate(tmpl, templateFile string) string {
+	if fsutil.IsExist(tmpl) {
+		ext := filepath.Ext(templateFile)
+		indexFile := strings.TrimSuffix(templateFile, ext) + "_index" + ext
+
+		if fsutil.IsExist(indexFile) {
+			return indexFile
+		}
+
+		return ""
+	}
+
+	return getPathToTemplate(tmpl + "_index")
+}
+
+// getOutputExt returns extension of output files for given template
+func getOutputExt(tmpl string) string {
+	name := strings.TrimSuffix(filepath.Base(tmpl), ".tpl")
+
+	switch name {
+	case "html":
+		return ".html"
+	case "markdown":
+		return ".md"
+	}
+
+	// Custom templates can define output extension in the name (page.html.tpl)
+	ext := filepath.Ext(name)
+
+	if ext == "" {
+		return ".txt"
+	}
+
+	return ext
+}
+
+// renderFile renders data with given template to output file
+func renderFile(t *template.Template, data any, output string) error {
+	fd, err := os.OpenFile(output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	defer fd.Close()
+
+	return t.Execute(fd, data)
+}
+
 // readTemplate reads template
 func readTemplate(templateFile string) (*template.Template, error) {
 	err := fsutil.ValidatePerms("FRS", templateFile)
@@ -112,3 +214,17 @@ func printDocumentStats(doc *script.Document, output string) {
 
 	fmtutil.Separator(false)
 }
+
+// printIndexStats prints information about index
+func printIndexStats(index *script.Index, output string) {
+	fmtutil.Separator(false, index.Title)
+
+	fmtc.Printfn("  {*}Scripts:{!}      %d", len(index.Scripts))
+	fmtc.Printfn("  {*}Undocumented:{!} %d", len(index.Undocumented))
+
+	fmtc.NewLine()
+
+	fmtc.Printfn("  {*}Index:{!} %s", output)
+
+	fmtutil.Separator(false)
+}
@@ -8,6 +8,7 @@ package terminal
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -25,12 +26,27 @@ var varExtractRegex = regexp.MustCompile(`\$\{*[^\}\n\r]+\}*`)
 
 // Render prints script info into terminal
 func Render(doc *script.Document, pattern string) error {
-	if pattern != "" {
-		renderPart(doc, pattern)
-	} else {
+	if pattern == "" {
 		renderAll(doc)
+		return nil
 	}
 
+	var section *script.Section
+
+	// Pattern with section filter (@section or @section:name)
+	if strings.HasPrefix(pattern, "@") {
+		var path string
+
+		path, pattern, _ = strings.Cut(pattern[1:], ":")
+		section = doc.FindSection(path)
+
+		if section == nil {
+			return fmt.Errorf("Can't find section %q", path)
+		}
+	}
+
+	renderPart(doc, section, pattern)
+
 	return nil
 }
 
@@ -41,21 +57,27 @@ func renderAll(doc *script.Document) {
 	if doc.HasAbout() {
 		fmtutil.Separator(false, "ABOUT")
 
-		for _, l := range doc.About {
-			fmtc.Printfn("  %s", l)
-		}
+		renderMarkup(doc.AboutMarkup(), "  ")
 	}
 
+	groups := doc.Groups()
+
 	if doc.HasConstants() {
 		fmtutil.Separator(false, "CONSTANTS")
 
-		totalConstants := len(doc.Constants)
+		for i, g := range groups {
+			if !g.HasConstants() {
+				continue
+			}
+
+			renderSection(doc, g, i)
 
-		for i, c := range doc.Constants {
-			renderConstant(c)
+			for j, c := range g.Constants {
+				renderConstant(doc, c)
 
-			if i < totalConstants-1 {
-				fmtc.NewLine()
+				if j < len(g.Constants)-1 {
+					fmtc.NewLine()
+				}
 			}
 		}
 	}
@@ -63,13 +85,19 @@ func renderAll(doc *script.Document) {
 	if doc.HasVariables() {
 		fmtutil.Separator(false, "GLOBAL VARIABLES")
 
-		totalVariables := len(doc.Variables)
+		for i, g := range groups {
+			if !g.HasVariables() {
+				continue
+			}
 
-		for i, v := range doc.Variables {
-			renderVariable(v)
+			renderSection(doc, g, i)
 
-			if i < totalVariables-1 {
-				fmtc.NewLine()
+			for j, v := range g.Variables {
+				renderVariable(doc, v)
+
+				if j < len(g.Variables)-1 {
+					fmtc.NewLine()
+				}
 			}
 		}
 	}
@@ -77,14 +105,20 @@ func renderAll(doc *script.Document) {
 	if doc.HasMethods() {
 		fmtutil.Separator(false, "METHODS")
 
-		totalMethods := len(doc.Methods)
+		for i, g := range groups {
+			if !g.HasMethods() {
+				continue
+			}
 
-		for i, m := range doc.Methods {
-			renderMethod(m, false)
+			renderSection(doc, g, i)
 
-			if i < totalMethods-1 {
-				fmtc.Println("\n{s-}" + strings.Repeat("-", 88) + "{!}")
-				fmtc.NewLine()
+			for j, m := range g.Methods {
+				renderMethod(doc, m, false)
+
+				if j < len(g.Methods)-1 {
+					fmtc.Println("\n{s-}" + strings.Repeat("-", 88) + "{!}")
+					fmtc.NewLine()
+				}
 			}
 		}
 	}
@@ -93,13 +127,17 @@ func renderAll(doc *script.Document) {
 }
 
 // renderPart renders only part of document (method/variable/constant)
-func renderPart(doc *script.Document, pattern string) {
+func renderPart(doc *script.Document, section *script.Section, pattern string) {
 	fmtc.NewLine()
 
+	if section != nil {
+		renderSection(doc, section, 0)
+	}
+
 	if doc.Constants != nil {
 		for _, c := range doc.Constants {
-			if strings.Contains(c.Name, pattern) {
-				renderConstant(c)
+			if isMatch(c.Name, c.Section, section, pattern) {
+				renderConstant(doc, c)
 				fmtc.NewLine()
 			}
 		}
@@ -107,8 +145,8 @@ func renderPart(doc *script.Document, pattern string) {
 
 	if doc.Variables != nil {
 		for _, v := range doc.Variables {
-			if strings.Contains(v.Name, pattern) {
-				renderVariable(v)
+			if isMatch(v.Name, v.Section, section, pattern) {
+				renderVariable(doc, v)
 				fmtc.NewLine()
 			}
 		}
@@ -116,53 +154,164 @@ func renderPart(doc *script.Document, pattern string) {
 
 	if doc.Methods != nil {
 		for _, m := range doc.Methods {
-			if strings.Contains(m.Name, pattern) {
-				renderMethod(m, true)
+			if isMatch(m.Name, m.Section, section, pattern) {
+				renderMethod(doc, m, true)
 				fmtc.NewLine()
 			}
 		}
 	}
 }
 
+// renderSection prints section name and description to console
+func renderSection(doc *script.Document, s *script.Section, index int) {
+	if s.Name == "" {
+		return
+	}
+
+	if index != 0 {
+		fmtc.NewLine()
+	}
+
+	fmtc.Printfn("  {*@} %s {!}", strings.ReplaceAll(s.Path, "/", " › "))
+
+	if len(s.Desc) != 0 {
+		renderMarkup(doc.MarkupOf(s.Desc), "  ")
+	}
+
+	fmtc.NewLine()
+}
+
 // renderConstant prints constant info to console
-func renderConstant(c *script.Variable) {
-	fmtc.Printfn("{s}%4d:{!} {m*}%s{!} {s}={!} "+colorizeValue(c.Value)+" "+getVarTypeDesc(c.Type), c.Line, c.Name)
-	fmtc.Printfn("      %s", c.UnitedDesc())
+func renderConstant(doc *script.Document, c *script.Variable) {
+	format, args := colorizeValue(formatValue(c.Value))
+
+	fmtc.Printfn(
+		formatLine(doc, c.File, c.Line)+" {m*}"+getNameFormat(c.IsDeprecated())+"{!} {s}={!} "+format+" "+getVarTypeDesc(c.Type),
+		append([]any{c.Name}, args...)...,
+	)
+
+	renderMarkup(doc.MarkupOf(c.Desc), "      ")
+	renderTags(&c.Tags, "      ")
+	renderElements(c)
 }
 
 // renderMethod prints variable info to console
-func renderVariable(v *script.Variable) {
-	fmtc.Printfn("{s}%4d:{!} {c*}%s{!} {s}={!} "+colorizeValue(v.Value)+" "+getVarTypeDesc(v.Type), v.Line, v.Name)
-	fmtc.Printfn("      %s", v.UnitedDesc())
+func renderVariable(doc *script.Document, v *script.Variable) {
+	format, args := colorizeValue(formatValue(v.Value))
+
+	fmtc.Printfn(
+		formatLine(doc, v.File, v.Line)+" {c*}"+getNameFormat(v.IsDeprecated())+"{!} {s}={!} "+format+" "+getVarTypeDesc(v.Type),
+		append([]any{v.Name}, args...)...,
+	)
+
+	renderMarkup(doc.MarkupOf(v.Desc), "      ")
+	renderTags(&v.Tags, "      ")
+	renderElements(v)
+}
+
+// renderElements prints array or map elements to console
+func renderElements(v *script.Variable) {
+	if !v.HasElements() {
+		return
+	}
+
+	fmtc.NewLine()
+
+	for _, e := range v.Elements {
+		format, args := colorizeValue(e.Value)
+
+		switch {
+		case v.IsMap() || e.Key != "":
+			fmtc.Printfn("      {s-}•{!} {*}%s{!} {s}→{!} "+format, append([]any{e.Key}, args...)...)
+		default:
+			fmtc.Printfn("      {s-}•{!} "+format, args...)
+		}
+	}
 }
 
 // renderMethod prints method info to console
-func renderMethod(m *script.Method, showExamples bool) {
-	fmtc.Printfn("{s}%4d:{!} {b*}%s{!} {s}-{!} %s", m.Line, m.Name, m.UnitedDesc())
+func renderMethod(doc *script.Document, m *script.Method, showExamples bool) {
+	desc := doc.MarkupOf(m.Desc)
+	format, args := formatText(desc.Head())
+
+	fmtc.Printfn(
+		formatLine(doc, m.File, m.Line)+" {b*}"+getNameFormat(m.IsDeprecated())+"{!} {s}-{!} "+format,
+		append([]any{m.Name}, args...)...,
+	)
+
+	if !desc.Tail().IsEmpty() {
+		fmtc.NewLine()
+		renderMarkup(desc.Tail(), "  ")
+	}
+
+	if m.HasTags() {
+		fmtc.NewLine()
+		renderTags(&m.Tags, "  ")
+	}
 
 	if len(m.Arguments) != 0 {
 		fmtc.NewLine()
 
+		if showExamples {
+			fmtc.Printfn("  {*}Usage:{!} %s", m.Signature())
+			fmtc.NewLine()
+		}
+
 		for _, a := range m.Arguments {
-			switch {
-			case a.IsOptional:
-				fmtc.Printfn("  {s-}%2s.{!} %s "+getVarTypeDesc(a.Type)+" {s-}[Optional]{!}", a.Index, a.Desc)
-			case a.IsWildcard:
-				fmtc.Printfn("  {s-}%2s.{!} %s", a.Index, a.Desc)
-			default:
-				fmtc.Printfn("  {s-}%2s.{!} %s "+getVarTypeDesc(a.Type), a.Index, a.Desc)
-			}
+			renderArgument(a)
 		}
 	}
 
-	if m.ResultCode {
+	if m.HasCodes() {
 		fmtc.NewLine()
-		fmtc.Printfn("    {*}Code:{!} 0 - ok, 1 - not ok")
+		fmtc.Println("  {*}Code:{!}")
+
+		for _, code := range m.Codes() {
+			fmtc.Printfn("  {s-}%3d{!} %s", code, formatDesc(m.CodeLines(code), 6))
+		}
 	}
 
 	if m.ResultEcho != nil {
 		fmtc.NewLine()
-		fmtc.Printfn("  {*}Echo:{!} %s "+getVarTypeDesc(m.ResultEcho.Type), strings.Join(m.ResultEcho.Desc, " "))
+		fmtc.Printfn("  {*}Echo:{!} %s "+getVarTypeDesc(m.ResultEcho.Type), formatDesc(m.ResultEcho.Desc, 8))
+	}
+
+	if m.HasStderr() {
+		fmtc.NewLine()
+		fmtc.Printfn("  {*}Stderr:{!} %s", formatDesc(m.Stderr, 10))
+	}
+
+	if m.HasFiles() {
+		fmtc.NewLine()
+		fmtc.Println("  {*}Files:{!}")
+
+		for _, f := range m.Files {
+			if f.Desc == "" {
+				fmtc.Printfn("    {s-}•{!} %s", f.Path)
+			} else {
+				fmtc.Printfn("    {s-}•{!} %s {s}-{!} %s", f.Path, f.Desc)
+			}
+		}
+	}
+
+	if m.HasReads() {
+		fmtc.NewLine()
+		renderVariableRefs(doc, "Reads", m.Reads)
+	}
+
+	if m.HasModifies() {
+		fmtc.NewLine()
+		renderVariableRefs(doc, "Modifies", m.Modifies)
+	}
+
+	if m.HasCalls() {
+		fmtc.NewLine()
+		fmtc.Println("  {*}Calls:{!} " + formatNames(m.Calls))
+	}
+
+	if m.HasCallers() {
+		fmtc.NewLine()
+		fmtc.Println("  {*}Called by:{!} " + formatNames(m.CalledBy))
 	}
 
 	if m.Example != nil && showExamples {
@@ -176,27 +325,231 @@ func renderMethod(m *script.Method, showExamples bool) {
 	}
 }
 
-// colorizeValue adds color tags based on variable value
-func colorizeValue(value string) string {
-	if !varExtractRegex.MatchString(value) {
-		return value
+// renderArgument prints argument info to console
+func renderArgument(a *script.Argument) {
+	format := "  {s-}%2s.{!} " + getArgNameFormat(a.Name) + "%s"
+	args := []any{a.Index, formatDesc(a.DescLines(), 6)}
+
+	if !a.IsUnknown() {
+		format += " " + getVarTypeDesc(a.Type)
+	}
+
+	if a.Constraints() != "" {
+		format += " {s}%s{!}"
+		args = append(args, a.Constraints())
 	}
 
-	return varExtractRegex.ReplaceAllStringFunc(value, func(v string) string {
-		return "{g}" + v + "{!}"
-	})
+	switch {
+	case a.HasDefault():
+		format += " {s-}[Default: %s]{!}"
+		args = append(args, a.Default)
+	case a.IsOptional:
+		format += " {s-}[Optional]{!}"
+	}
+
+	fmtc.Printfn(format, args...)
+}
+
+// renderVariableRefs prints list of variables used by method to console
+func renderVariableRefs(doc *script.Document, title string, refs []*script.VariableRef) {
+	fmtc.Printfn("  {*}%s:{!}", title)
+
+	for _, r := range refs {
+		desc := doc.DescOf(r)
+
+		if desc == "" {
+			fmtc.Printfn("    {s-}•{!} {c}%s{!}", r.Name)
+		} else {
+			fmtc.Printfn("    {s-}•{!} {c}%s{!} {s}-{!} %s", r.Name, desc)
+		}
+	}
+}
+
+// renderMarkup prints description blocks to console
+func renderMarkup(m script.Markup, indent string) {
+	if m.IsEmpty() {
+		fmtc.Println(indent)
+		return
+	}
+
+	for i, b := range m {
+		if i != 0 {
+			fmtc.NewLine()
+		}
+
+		switch {
+		case b.IsCode():
+			for _, l := range b.Code {
+				fmtc.Printfn(indent+"  {s}%s{!}", l)
+			}
+
+		case b.IsList(), b.IsNumbered():
+			for j, item := range b.Items {
+				marker := "{s-}•{!} "
+
+				if b.IsNumbered() {
+					marker = fmt.Sprintf("{s-}%d.{!} ", j+1)
+				}
+
+				format, args := formatText(item)
+				fmtc.Printfn(indent+marker+format, args...)
+			}
+
+		default:
+			format, args := formatText(b.Text())
+			fmtc.Printfn(indent+format, args...)
+		}
+	}
+}
+
+// renderTags prints info from entity tags to console
+func renderTags(t *script.Tags, indent string) {
+	if t.IsDeprecated() {
+		format := indent + "{r*}Deprecated{!}"
+		var args []any
+
+		if t.Deprecated.Replacement != "" {
+			format += "{r}, use{!} {b}%s{!} {r}instead{!}"
+			args = append(args, t.Deprecated.Replacement)
+		}
+
+		if t.Deprecated.Note != "" {
+			format += "{r}: %s{!}"
+			args = append(args, t.Deprecated.Note)
+		}
+
+		fmtc.Printfn(format, args...)
+	}
+
+	if t.Since != "" {
+		fmtc.Printfn(indent+"{*}Since:{!} %s", t.Since)
+	}
+
+	if t.HasSee() {
+		fmtc.Printfn(indent+"{*}See:{!} %s", strings.Join(t.See, ", "))
+	}
+
+	if t.Author != "" {
+		fmtc.Printfn(indent+"{*}Author:{!} %s", t.Author)
+	}
+}
+
+// isMatch returns true if entity with given name and section matches
+// section filter and name pattern
+func isMatch(name, entitySection string, section *script.Section, pattern string) bool {
+	if section != nil && !section.Contains(entitySection) {
+		return false
+	}
+
+	return strings.Contains(name, pattern)
+}
+
+// formatLine formats line number of entity definition
+func formatLine(doc *script.Document, file string, line int) string {
+	origin := doc.OriginOf(file)
+
+	if origin == "" {
+		return fmt.Sprintf("{s}%4d:{!}", line)
+	}
+
+	return fmt.Sprintf("{s}%s:%d:{!}", origin, line)
+}
+
+// formatText returns format and arguments for printing text with inline code,
+// references to documented entities are underlined
+func formatText(t script.Text) (string, []any) {
+	var format string
+	var args []any
+
+	for _, s := range t {
+		switch {
+		case s.Link != "":
+			format += "{c_}%s{!}"
+		case s.IsCode:
+			format += "{c}%s{!}"
+		default:
+			format += "%s"
+		}
+
+		args = append(args, s.Text)
+	}
+
+	return format, args
+}
+
+// formatNames formats list of methods names
+func formatNames(names []string) string {
+	return "{b}" + strings.Join(names, "{!}{s},{!} {b}") + "{!}"
+}
+
+// formatDesc aligns lines of multiline description
+func formatDesc(desc []string, indent int) string {
+	lines := make([]string, len(desc))
+
+	for i, line := range desc {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+
+	return strings.Join(lines, "\n"+strings.Repeat(" ", indent))
+}
+
+// formatValue aligns lines of multiline value
+func formatValue(value string) string {
+	return strings.ReplaceAll(value, "\n", "\n      ")
+}
+
+// colorizeValue returns format and arguments for printing value, variables
+// used in value are highlighted
+func colorizeValue(value string) (string, []any) {
+	var format string
+	var args []any
+	var start int
+
+	for _, loc := range varExtractRegex.FindAllStringIndex(value, -1) {
+		if loc[0] > start {
+			format += "%s"
+			args = append(args, value[start:loc[0]])
+		}
+
+		format += "{g}%s{!}"
+		args = append(args, value[loc[0]:loc[1]])
+		start = loc[1]
+	}
+
+	if start < len(value) {
+		format += "%s"
+		args = append(args, value[start:])
+	}
+
+	return format, args
+}
+
+// getNameFormat returns format of entity name, deprecated entities are
+// struck through
+func getNameFormat(isDeprecated bool) string {
+	if isDeprecated {
+		return "{=}%s{!=}"
+	}
+
+	return "%s"
+}
+
+// getArgNameFormat returns format of argument name
+func getArgNameFormat(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	return "{c}" + name + "{!} "
 }
 
 // getVarTypeDesc returns type description
 func getVarTypeDesc(t script.VariableType) string {
-	switch t {
-	case script.VAR_TYPE_STRING:
-		return "{b}({&}String{!&}){!}"
-	case script.VAR_TYPE_NUMBER:
-		return "{y}({&}Number{!&}){!}"
-	case script.VAR_TYPE_BOOLEAN:
-		return "{g}({&}Boolean{!&}){!}"
-	default:
+	info := script.GetTypeInfo(t)
+
+	if info == nil {
 		return ""
 	}
+
+	return "{" + info.Term + "}({&}" + info.Name + "{!&}){!}"
 }
@@ -0,0 +1,372 @@
+package script
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// BlockType is type of description block
+type BlockType uint8
+
+const (
+	BLOCK_PARAGRAPH BlockType = 0
+	BLOCK_LIST      BlockType = 1
+	BLOCK_NUMBERED  BlockType = 2
+	BLOCK_CODE      BlockType = 3
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Markup is description split into blocks
+type Markup []*Block
+
+// Block contains part of description
+type Block struct {
+	Type  BlockType `json:"type"`  // Type
+	Items []Text    `json:"items"` // Text of paragraph or list items
+	Code  []string  `json:"code"`  // Lines of code block
+}
+
+// Text is text with inline code
+type Text []*Span
+
+// Span contains part of text
+type Span struct {
+	Text   string `json:"text"` // Text
+	IsCode bool   `json:"code"` // Inline code
+	Ref    string `json:"ref"`  // Name of referenced entity
+	Link   string `json:"link"` // Anchor of referenced entity (empty if entity is unknown)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// markupParser contains state of description parser
+type markupParser struct {
+	result Markup
+	kind   BlockType
+	lines  []string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// LinkRegExp is regexp for explicit reference to entity ({@link name})
+var LinkRegExp = regexp.MustCompile(`\{@link[ \t]+(\$\{[^\s}]+\}|[^\s}]+)[ \t]*\}`)
+
+var (
+	bulletRegExp   = regexp.MustCompile(`^[-*+][ \t]+(.*)$`)
+	numberedRegExp = regexp.MustCompile(`^[0-9]{1,}[.)][ \t]+(.*)$`)
+	refNameRegExp  = regexp.MustCompile(`^\$?\{?([a-zA-Z_][a-zA-Z0-9._:]*)\}?(?:\(\))?$`)
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ParseMarkup parses description lines to markup blocks
+func ParseMarkup(data []string) Markup {
+	p := &markupParser{}
+
+	for i := 0; i < len(data); i++ {
+		line := strings.TrimRight(data[i], " \t")
+		text := strings.TrimLeft(line, " \t")
+		indent := getIndent(line)
+
+		switch {
+		case text == "":
+			p.flush()
+
+		case strings.HasPrefix(text, "```"):
+			p.flush()
+			i += p.readFence(data[i+1:], indent)
+
+		case bulletRegExp.MatchString(text):
+			p.addItem(BLOCK_LIST, bulletRegExp.FindStringSubmatch(text)[1])
+
+		case numberedRegExp.MatchString(text):
+			p.addItem(BLOCK_NUMBERED, numberedRegExp.FindStringSubmatch(text)[1])
+
+		case p.isList() && indent >= 2:
+			p.lines[len(p.lines)-1] += " " + text
+
+		case indent >= 4 && len(p.lines) == 0:
+			i += p.readCode(data[i:]) - 1
+
+		default:
+			if p.isList() {
+				p.flush()
+			}
+
+			p.kind = BLOCK_PARAGRAPH
+			p.lines = append(p.lines, text)
+		}
+	}
+
+	p.flush()
+
+	return p.result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Spans returns all spans from all blocks
+func (m Markup) Spans() []*Span {
+	var result []*Span
+
+	for _, b := range m {
+		for _, t := range b.Items {
+			result = append(result, t...)
+		}
+	}
+
+	return result
+}
+
+// IsEmpty returns true if markup doesn't contain any blocks
+func (m Markup) IsEmpty() bool {
+	return len(m) == 0
+}
+
+// Head returns text of the first paragraph
+func (m Markup) Head() Text {
+	if len(m) == 0 || !m[0].IsParagraph() {
+		return nil
+	}
+
+	return m[0].Text()
+}
+
+// Tail returns all blocks except the first paragraph
+func (m Markup) Tail() Markup {
+	if len(m) == 0 {
+		return nil
+	}
+
+	if !m[0].IsParagraph() {
+		return m
+	}
+
+	if len(m) == 1 {
+		return nil
+	}
+
+	return m[1:]
+}
+
+// IsParagraph returns true if block is paragraph
+func (b *Block) IsParagraph() bool {
+	return b != nil && b.Type == BLOCK_PARAGRAPH
+}
+
+// IsList returns true if block is bullet list
+func (b *Block) IsList() bool {
+	return b != nil && b.Type == BLOCK_LIST
+}
+
+// IsNumbered returns true if block is numbered list
+func (b *Block) IsNumbered() bool {
+	return b != nil && b.Type == BLOCK_NUMBERED
+}
+
+// IsCode returns true if block is code block
+func (b *Block) IsCode() bool {
+	return b != nil && b.Type == BLOCK_CODE
+}
+
+// Text returns text of paragraph
+func (b *Block) Text() Text {
+	if b == nil || len(b.Items) == 0 {
+		return nil
+	}
+
+	return b.Items[0]
+}
+
+// String returns text without markup
+func (t Text) String() string {
+	var result strings.Builder
+
+	for _, s := range t {
+		result.WriteString(s.Text)
+	}
+
+	return result.String()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isList returns true if parser reads list
+func (p *markupParser) isList() bool {
+	return len(p.lines) != 0 && (p.kind == BLOCK_LIST || p.kind == BLOCK_NUMBERED)
+}
+
+// addItem adds item to list with given type
+func (p *markupParser) addItem(kind BlockType, text string) {
+	if p.kind != kind {
+		p.flush()
+	}
+
+	p.kind = kind
+	p.lines = append(p.lines, text)
+}
+
+// readFence reads fenced code block and returns number of used lines
+func (p *markupParser) readFence(data []string, indent int) int {
+	block := &Block{Type: BLOCK_CODE}
+
+	for index, line := range data {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			p.result = append(p.result, block)
+			return index + 1
+		}
+
+		block.Code = append(block.Code, trimIndent(line, indent))
+	}
+
+	p.result = append(p.result, block)
+
+	return len(data)
+}
+
+// readCode reads indented code block and returns number of used lines
+func (p *markupParser) readCode(data []string) int {
+	block := &Block{Type: BLOCK_CODE}
+
+	var lines int
+
+	for index, line := range data {
+		if strings.TrimSpace(line) == "" {
+			// Empty line is a part of code only if code continues after it
+			if index+1 < len(data) && getIndent(data[index+1]) >= 4 {
+				block.Code = append(block.Code, "")
+				lines++
+				continue
+			}
+
+			break
+		}
+
+		if getIndent(line) < 4 {
+			break
+		}
+
+		block.Code = append(block.Code, strings.TrimRight(trimIndent(line, 4), " \t"))
+		lines++
+	}
+
+	p.result = append(p.result, block)
+
+	return lines
+}
+
+// flush adds current paragraph or list to result
+func (p *markupParser) flush() {
+	if len(p.lines) == 0 {
+		return
+	}
+
+	block := &Block{Type: p.kind}
+
+	switch p.kind {
+	case BLOCK_PARAGRAPH:
+		block.Items = []Text{parseText(strings.Join(p.lines, " "))}
+	default:
+		for _, line := range p.lines {
+			block.Items = append(block.Items, parseText(line))
+		}
+	}
+
+	p.result = append(p.result, block)
+	p.kind, p.lines = BLOCK_PARAGRAPH, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseText splits text to spans with inline code and links
+func parseText(text string) Text {
+	var result Text
+	var start int
+
+	for _, loc := range LinkRegExp.FindAllStringSubmatchIndex(text, -1) {
+		name := text[loc[2]:loc[3]]
+
+		result = append(result, parseCode(text[start:loc[0]])...)
+		result = append(result, &Span{Text: name, IsCode: true, Ref: name})
+
+		start = loc[1]
+	}
+
+	return append(result, parseCode(text[start:])...)
+}
+
+// parseCode splits text to spans with inline code
+func parseCode(text string) Text {
+	var result Text
+
+	parts := strings.Split(text, "`")
+
+	// Unpaired backtick is a part of text
+	if len(parts)%2 == 0 {
+		parts[len(parts)-2] += "`" + parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+
+	for index, part := range parts {
+		if part == "" {
+			continue
+		}
+
+		result = append(result, &Span{Text: part, IsCode: index%2 == 1})
+	}
+
+	return result
+}
+
+// getRefName returns name of entity from reference ($VAR, ${VAR}, method())
+func getRefName(ref string) string {
+	if !refNameRegExp.MatchString(ref) {
+		return ""
+	}
+
+	return refNameRegExp.FindStringSubmatch(ref)[1]
+}
+
+// getIndent returns size of line indentation (tab is 4 spaces)
+func getIndent(line string) int {
+	var result int
+
+	for _, r := range line {
+		switch r {
+		case ' ':
+			result++
+		case '\t':
+			result += 4
+		default:
+			return result
+		}
+	}
+
+	return result
+}
+
+// trimIndent removes up to given number of leading spaces from line
+func trimIndent(line string, indent int) string {
+	for range indent {
+		switch {
+		case strings.HasPrefix(line, " "):
+			line = line[1:]
+		case strings.HasPrefix(line, "\t"):
+			return line[1:]
+		default:
+			return line
+		}
+	}
+
+	return line
+}
@@ -7,7 +7,15 @@ package script
 //                                                                                    //
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-import "github.com/essentialkaos/ek/v13/mathutil"
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/mathutil"
+)
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
@@ -19,6 +27,12 @@ const (
 	VAR_TYPE_STRING  VariableType = 1
 	VAR_TYPE_NUMBER  VariableType = 2
 	VAR_TYPE_BOOLEAN VariableType = 3
+	VAR_TYPE_ARRAY   VariableType = 4
+	VAR_TYPE_MAP     VariableType = 5
+	VAR_TYPE_PATH    VariableType = 6
+	VAR_TYPE_FILE    VariableType = 7
+	VAR_TYPE_DIR     VariableType = 8
+	VAR_TYPE_URL     VariableType = 9
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -31,53 +45,154 @@ const (
 	VAR_MOD_LOWERCASE_SHORT int = 4
 )
 
+// ARG_INDEX_ANY is last position of open-ended argument range
+const ARG_INDEX_ANY = -1
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // Method contains info about method
 type Method struct {
-	Name       string      `json:"name"`        // Name
-	Desc       []string    `json:"desc"`        // Description
-	Arguments  []*Argument `json:"arguments"`   // Arguments
-	ResultCode bool        `json:"result_code"` // Method uses exit codes
-	ResultEcho *Variable   `json:"result_echo"` // Return argument
-	Example    []string    `json:"example"`     // Example
-	Line       int         `json:"line"`        // LOC of definition
+	Name        string         `json:"name"`         // Name
+	Desc        []string       `json:"desc"`         // Description
+	Arguments   []*Argument    `json:"arguments"`    // Arguments
+	ResultCodes map[int]string `json:"result_codes"` // Exit codes with meanings
+	ResultEcho  *Variable      `json:"result_echo"`  // Return argument
+	Stderr      []string       `json:"stderr"`       // Data printed to stderr
+	Files       []*File        `json:"files"`        // Files created or modified by method
+	Reads       []*VariableRef `json:"reads"`        // Variables read by method
+	Modifies    []*VariableRef `json:"modifies"`     // Variables modified by method
+	Example     []string       `json:"example"`      // Example
+	Line        int            `json:"line"`         // LOC of definition
+	File        string         `json:"file"`         // Path to script with definition
+	Calls       []string       `json:"calls"`        // Documented methods called by method
+	CalledBy    []string       `json:"called_by"`    // Documented methods which call method
+	Section     string         `json:"section"`      // Path of section
+
+	Tags
 }
 
 // Argument contains info about method argument
 type Argument struct {
-	Index      string       `json:"index"`    // Index
+	Index      string       `json:"index"`    // Index (1, 2..3, 2..*, *)
+	From       int          `json:"from"`     // First position
+	To         int          `json:"to"`       // Last position (ARG_INDEX_ANY for open-ended ranges)
+	Name       string       `json:"name"`     // Name
 	Desc       string       `json:"desc"`     // Desc
 	Type       VariableType `json:"type"`     // Type
 	IsOptional bool         `json:"optional"` // Optional
-	IsWildcard bool         `json:"wildcard"` // Wildcard
+	Default    string       `json:"default"`  // Default value
+	Min        *int         `json:"min"`      // Minimal value
+	Max        *int         `json:"max"`      // Maximal value
+	Enum       []string     `json:"enum"`     // Allowed values
 }
 
 // Variable contains info about variable
 type Variable struct {
-	Name  string       `json:"name"`  // Name
-	Desc  []string     `json:"desc"`  // Description
-	Type  VariableType `json:"type"`  // Type
-	Value string       `json:"value"` // Value
-	Line  int          `json:"line"`  // LOC of definition
+	Name       string       `json:"name"`     // Name
+	Desc       []string     `json:"desc"`     // Description
+	Type       VariableType `json:"type"`     // Type
+	Value      string       `json:"value"`    // Value
+	Line       int          `json:"line"`     // LOC of definition
+	File       string       `json:"file"`     // Path to script with definition
+	IsReadOnly bool         `json:"readonly"` // Read-only (declare -r, readonly)
+	IsExported bool         `json:"exported"` // Exported (declare -x, export)
+	IsInteger  bool         `json:"integer"`  // Integer (declare -i)
+	IsIndexed  bool         `json:"indexed"`  // Indexed array (declare -a)
+	IsAssoc    bool         `json:"assoc"`    // Associative array (declare -A)
+	Elements   []*Element   `json:"elements"` // Array or map elements
+	Section    string       `json:"section"`  // Path of section
+
+	Tags
+}
+
+// Tags contains info from structured tags (@deprecated, @since, @see, @author)
+type Tags struct {
+	Deprecated *Deprecation `json:"deprecated"` // Deprecation info
+	Since      string       `json:"since"`      // Version entity was introduced in
+	See        []string     `json:"see"`        // References to related entities
+	Author     string       `json:"author"`     // Author
+}
+
+// Deprecation contains info about deprecation
+type Deprecation struct {
+	Replacement string `json:"replacement"` // Name of entity which must be used instead
+	Note        string `json:"note"`        // Deprecation note
+}
+
+// Element contains info about array or map element
+type Element struct {
+	Key   string `json:"key"`   // Key or index (only if defined explicitly)
+	Value string `json:"value"` // Value
+}
+
+// File contains info about file created or modified by method
+type File struct {
+	Path string `json:"path"` // Path to file
+	Desc string `json:"desc"` // Description
+}
+
+// VariableRef contains info about variable used by method
+type VariableRef struct {
+	Name       string `json:"name"`     // Variable name
+	Desc       string `json:"desc"`     // Description
+	IsDetected bool   `json:"detected"` // Usage detected from method body
+}
+
+// Include contains info about sourced script
+type Include struct {
+	Path string `json:"path"` // Path as written in script
+	File string `json:"file"` // Resolved path (empty if path can't be resolved)
+	Line int    `json:"line"` // LOC of source command
 }
 
 // Document contains info about all constants, global variables and methods
 type Document struct {
 	Title     string      `json:"title"`
+	File      string      `json:"file"`
+	Shell     string      `json:"shell"`
 	About     []string    `json:"about"`
 	Constants []*Variable `json:"constants"`
 	Variables []*Variable `json:"variables"`
 	Methods   []*Method   `json:"methods"`
+	Sections  []*Section  `json:"sections"`
+	Includes  []*Include  `json:"includes"`
+}
+
+// Section contains info about group of entities
+type Section struct {
+	Name      string      `json:"name"`      // Name
+	Path      string      `json:"path"`      // Full path (Parent/Name)
+	Desc      []string    `json:"desc"`      // Description
+	Line      int         `json:"line"`      // LOC of definition
+	File      string      `json:"file"`      // Path to script with definition
+	Constants []*Variable `json:"constants"` // Constants from section
+	Variables []*Variable `json:"variables"` // Global variables from section
+	Methods   []*Method   `json:"methods"`   // Methods from section
+	Sections  []*Section  `json:"sections"`  // Subsections
+}
+
+// Index contains info about all scripts in directory
+type Index struct {
+	Title        string        `json:"title"`        // Title
+	Scripts      []*IndexEntry `json:"scripts"`      // Documented scripts
+	Undocumented []string      `json:"undocumented"` // Scripts without documentation
+}
+
+// IndexEntry contains info about documented script
+type IndexEntry struct {
+	Path     string    `json:"path"`     // Path to script relative to directory
+	Link     string    `json:"link"`     // Path to output file relative to output directory
+	Document *Document `json:"document"` // Script documentation
+}
+
+// DocumentSet contains documents for script and all sourced scripts
+type DocumentSet struct {
+	Documents []*Document `json:"documents"` // Documents (first is the root one)
 }
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
-var (
-	typeNameString  = []string{"String", "string", "STRING", "S", "s"}
-	typeNameNumber  = []string{"Number", "number", "NUMBER", "N", "n"}
-	typeNameBoolean = []string{"Boolean", "boolean", "BOOLEAN", "B", "b"}
-)
+var anchorReplacer = strings.NewReplacer("/", "-", ".", "-", " ", "-")
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
@@ -102,6 +217,38 @@ func (d *Document) HasAbout() bool {
 	return d.About != nil
 }
 
+// AboutMarkup returns info about script with markup
+func (d *Document) AboutMarkup() Markup {
+	if d == nil {
+		return nil
+	}
+
+	return d.MarkupOf(d.About)
+}
+
+// MarkupOf returns markup for given description with resolved references
+// to documented entities
+func (d *Document) MarkupOf(desc []string) Markup {
+	if d == nil {
+		return nil
+	}
+
+	m := ParseMarkup(desc)
+
+	for _, s := range m.Spans() {
+		switch {
+		case s.Ref != "":
+			s.Link = d.LinkOf(s.Ref)
+		case s.IsCode:
+			if link := d.LinkOf(s.Text); link != "" {
+				s.Ref, s.Link = s.Text, link
+			}
+		}
+	}
+
+	return m
+}
+
 // HasConstants return true if doc has constants info
 func (d *Document) HasConstants() bool {
 	if d == nil {
@@ -129,6 +276,403 @@ func (d *Document) HasMethods() bool {
 	return d.Methods != nil
 }
 
+// HasSections return true if document contains sections
+func (d *Document) HasSections() bool {
+	if d == nil {
+		return false
+	}
+
+	return len(d.Sections) != 0
+}
+
+// FindSection returns section with given path (case-insensitive)
+func (d *Document) FindSection(path string) *Section {
+	if d == nil || path == "" {
+		return nil
+	}
+
+	return findSection(d.Sections, path)
+}
+
+// Groups returns entities grouped by sections. The first group without name
+// contains entities defined outside of sections.
+func (d *Document) Groups() []*Section {
+	if d == nil {
+		return nil
+	}
+
+	var result []*Section
+
+	root := &Section{}
+
+	for _, c := range d.Constants {
+		if c.Section == "" {
+			root.Constants = append(root.Constants, c)
+		}
+	}
+
+	for _, v := range d.Variables {
+		if v.Section == "" {
+			root.Variables = append(root.Variables, v)
+		}
+	}
+
+	for _, m := range d.Methods {
+		if m.Section == "" {
+			root.Methods = append(root.Methods, m)
+		}
+	}
+
+	if !root.IsEmpty() {
+		result = append(result, root)
+	}
+
+	return appendSections(result, d.Sections)
+}
+
+// HasIncludes return true if script sources other scripts
+func (d *Document) HasIncludes() bool {
+	if d == nil {
+		return false
+	}
+
+	return len(d.Includes) != 0
+}
+
+// OriginOf returns path to given script relative to the document script or
+// empty string if it is document script itself
+func (d *Document) OriginOf(file string) string {
+	if d == nil || file == "" || file == d.File {
+		return ""
+	}
+
+	rel, err := filepath.Rel(filepath.Dir(d.File), file)
+
+	if err != nil {
+		return file
+	}
+
+	return rel
+}
+
+// AnchorOf returns unique anchor for entity defined in given script on
+// given line
+func (d *Document) AnchorOf(file string, line int) string {
+	origin := d.OriginOf(file)
+
+	if origin == "" {
+		return strconv.Itoa(line)
+	}
+
+	return anchorReplacer.Replace(origin) + "-" + strconv.Itoa(line)
+}
+
+// LinkOf returns anchor of method, constant or global variable with given
+// name ($VAR, ${VAR} and method() forms are supported) or empty string if
+// there is no such entity
+func (d *Document) LinkOf(name string) string {
+	name = getRefName(name)
+
+	if d == nil || name == "" {
+		return ""
+	}
+
+	if m := d.FindMethod(name); m != nil {
+		return d.AnchorOf(m.File, m.Line)
+	}
+
+	if v := d.FindVariable(name); v != nil {
+		return d.AnchorOf(v.File, v.Line)
+	}
+
+	return ""
+}
+
+// DescOf returns description of variable reference, description of referenced
+// variable is used if reference doesn't have own description
+func (d *Document) DescOf(ref *VariableRef) string {
+	switch {
+	case ref == nil:
+		return ""
+	case ref.Desc != "":
+		return ref.Desc
+	}
+
+	return d.FindVariable(ref.Name).UnitedDesc()
+}
+
+// FindMethod returns method with given name
+func (d *Document) FindMethod(name string) *Method {
+	if d == nil {
+		return nil
+	}
+
+	for _, m := range d.Methods {
+		if m.Name == name {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// FindVariable returns global variable or constant with given name
+func (d *Document) FindVariable(name string) *Variable {
+	if d == nil {
+		return nil
+	}
+
+	for _, v := range slices.Concat(d.Constants, d.Variables) {
+		if v.Name == name {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// Root returns root document of set
+func (s *DocumentSet) Root() *Document {
+	if s == nil || len(s.Documents) == 0 {
+		return nil
+	}
+
+	return s.Documents[0]
+}
+
+// Find returns document for script with given path
+func (s *DocumentSet) Find(file string) *Document {
+	if s == nil {
+		return nil
+	}
+
+	for _, d := range s.Documents {
+		if d.File == file {
+			return d
+		}
+	}
+
+	return nil
+}
+
+// Merge merges all documents in set into one document
+func (s *DocumentSet) Merge() *Document {
+	root := s.Root()
+
+	if root == nil {
+		return nil
+	}
+
+	doc := &Document{
+		Title:    root.Title,
+		File:     root.File,
+		Shell:    root.Shell,
+		About:    root.About,
+		Includes: root.Includes,
+	}
+
+	for _, d := range s.Documents {
+		doc.Constants = append(doc.Constants, d.Constants...)
+		doc.Variables = append(doc.Variables, d.Variables...)
+		doc.Methods = append(doc.Methods, d.Methods...)
+		doc.Sections = append(doc.Sections, d.Sections...)
+	}
+
+	return doc
+}
+
+// HasConstants return true if section contains constants
+func (s *Section) HasConstants() bool {
+	if s == nil {
+		return false
+	}
+
+	return len(s.Constants) != 0
+}
+
+// HasVariables return true if section contains global variables
+func (s *Section) HasVariables() bool {
+	if s == nil {
+		return false
+	}
+
+	return len(s.Variables) != 0
+}
+
+// HasMethods return true if section contains methods
+func (s *Section) HasMethods() bool {
+	if s == nil {
+		return false
+	}
+
+	return len(s.Methods) != 0
+}
+
+// IsEmpty return true if section doesn't contain any entities
+func (s *Section) IsEmpty() bool {
+	return !s.HasConstants() && !s.HasVariables() && !s.HasMethods()
+}
+
+// Contains return true if entity with given section path belongs to section
+// or to one of its subsections
+func (s *Section) Contains(path string) bool {
+	if s == nil || path == "" {
+		return false
+	}
+
+	return strings.EqualFold(path, s.Path) ||
+		strings.HasPrefix(strings.ToLower(path), strings.ToLower(s.Path)+"/")
+}
+
+// Level returns nesting level of section
+func (s *Section) Level() int {
+	if s == nil {
+		return 0
+	}
+
+	return strings.Count(s.Path, "/")
+}
+
+// UnitedDesc return united description string
+func (s *Section) UnitedDesc() string {
+	if s == nil {
+		return ""
+	}
+
+	return mergeDesc(s.Desc)
+}
+
+// Markup returns description with markup
+func (s *Section) Markup() Markup {
+	if s == nil {
+		return nil
+	}
+
+	return ParseMarkup(s.Desc)
+}
+
+// HasScripts return true if index contains documented scripts
+func (i *Index) HasScripts() bool {
+	if i == nil {
+		return false
+	}
+
+	return len(i.Scripts) != 0
+}
+
+// HasUndocumented return true if index contains scripts without documentation
+func (i *Index) HasUndocumented() bool {
+	if i == nil {
+		return false
+	}
+
+	return len(i.Undocumented) != 0
+}
+
+// Summary return the first line of script about info
+func (e *IndexEntry) Summary() string {
+	if e == nil || !e.Document.HasAbout() || len(e.Document.About) == 0 {
+		return ""
+	}
+
+	return e.Document.About[0]
+}
+
+// IsVariadic return true if argument takes all remaining positions
+func (a *Argument) IsVariadic() bool {
+	if a == nil {
+		return false
+	}
+
+	return a.To == ARG_INDEX_ANY
+}
+
+// IsMultiple return true if argument takes more than one position
+func (a *Argument) IsMultiple() bool {
+	if a == nil {
+		return false
+	}
+
+	return a.IsVariadic() || a.To > a.From
+}
+
+// HasPosition return true if argument takes given position
+func (a *Argument) HasPosition(index int) bool {
+	if a == nil || index < a.From {
+		return false
+	}
+
+	return a.IsVariadic() || index <= a.To
+}
+
+// DescLines returns lines of argument description
+func (a *Argument) DescLines() []string {
+	if a == nil || a.Desc == "" {
+		return nil
+	}
+
+	return strings.Split(a.Desc, "\n")
+}
+
+// HasDefault return true if argument has default value
+func (a *Argument) HasDefault() bool {
+	if a == nil {
+		return false
+	}
+
+	return a.Default != ""
+}
+
+// HasRange return true if argument value has limits
+func (a *Argument) HasRange() bool {
+	if a == nil {
+		return false
+	}
+
+	return a.Min != nil || a.Max != nil
+}
+
+// HasEnum return true if argument has list of allowed values
+func (a *Argument) HasEnum() bool {
+	if a == nil {
+		return false
+	}
+
+	return len(a.Enum) != 0
+}
+
+// Range returns range of allowed values (min..max), unlimited bound is
+// shown as * like in index of variadic argument (2..*)
+func (a *Argument) Range() string {
+	if !a.HasRange() {
+		return ""
+	}
+
+	min, max := "*", "*"
+
+	if a.Min != nil {
+		min = strconv.Itoa(*a.Min)
+	}
+
+	if a.Max != nil {
+		max = strconv.Itoa(*a.Max)
+	}
+
+	return min + ".." + max
+}
+
+// Constraints returns description of argument value constraints
+func (a *Argument) Constraints() string {
+	switch {
+	case a.HasEnum():
+		return strings.Join(a.Enum, "|")
+	case a.HasRange():
+		return a.Range()
+	}
+
+	return ""
+}
+
 // TypeDesc return type description
 func (a *Argument) TypeName(mod int) string {
 	if a == nil {
@@ -138,6 +682,15 @@ func (a *Argument) TypeName(mod int) string {
 	return getTypeName(a.Type, mod)
 }
 
+// TypeColor return color of argument type
+func (a *Argument) TypeColor() string {
+	if a == nil {
+		return ""
+	}
+
+	return getTypeColor(a.Type)
+}
+
 // IsString return true if type is string
 func (a *Argument) IsString() bool {
 	if a == nil {
@@ -183,6 +736,15 @@ func (v *Variable) TypeName(mod int) string {
 	return getTypeName(v.Type, mod)
 }
 
+// TypeColor return color of variable type
+func (v *Variable) TypeColor() string {
+	if v == nil {
+		return ""
+	}
+
+	return getTypeColor(v.Type)
+}
+
 // IsString return true if type is string
 func (v *Variable) IsString() bool {
 	if v == nil {
@@ -210,6 +772,24 @@ func (v *Variable) IsBoolean() bool {
 	return v.Type == VAR_TYPE_BOOLEAN
 }
 
+// IsArray return true if type is array
+func (v *Variable) IsArray() bool {
+	if v == nil {
+		return false
+	}
+
+	return v.Type == VAR_TYPE_ARRAY
+}
+
+// IsMap return true if type is map (associative array)
+func (v *Variable) IsMap() bool {
+	if v == nil {
+		return false
+	}
+
+	return v.Type == VAR_TYPE_MAP
+}
+
 // IsUnknown return true if type is unknown
 func (v *Variable) IsUnknown() bool {
 	if v == nil {
@@ -219,6 +799,24 @@ func (v *Variable) IsUnknown() bool {
 	return v.Type == VAR_TYPE_UNKNOWN
 }
 
+// IsMultiline return true if variable value defined on multiple lines
+func (v *Variable) IsMultiline() bool {
+	if v == nil {
+		return false
+	}
+
+	return strings.Contains(v.Value, "\n")
+}
+
+// HasElements return true if variable has array or map elements
+func (v *Variable) HasElements() bool {
+	if v == nil {
+		return false
+	}
+
+	return len(v.Elements) != 0
+}
+
 // UnitedDesc return united description string
 func (v *Variable) UnitedDesc() string {
 	if v == nil {
@@ -228,6 +826,15 @@ func (v *Variable) UnitedDesc() string {
 	return mergeDesc(v.Desc)
 }
 
+// Markup returns description with markup
+func (v *Variable) Markup() Markup {
+	if v == nil {
+		return nil
+	}
+
+	return ParseMarkup(v.Desc)
+}
+
 // HasArguments return true if method has arguments
 func (m *Method) HasArguments() bool {
 	if m == nil {
@@ -237,6 +844,33 @@ func (m *Method) HasArguments() bool {
 	return m.Arguments != nil
 }
 
+// HasCodes return true if method uses exit codes
+func (m *Method) HasCodes() bool {
+	if m == nil {
+		return false
+	}
+
+	return len(m.ResultCodes) != 0
+}
+
+// Codes returns sorted slice with exit codes
+func (m *Method) Codes() []int {
+	if m == nil || len(m.ResultCodes) == 0 {
+		return nil
+	}
+
+	return slices.Sorted(maps.Keys(m.ResultCodes))
+}
+
+// CodeLines returns lines of exit code description
+func (m *Method) CodeLines(code int) []string {
+	if m == nil || m.ResultCodes[code] == "" {
+		return nil
+	}
+
+	return strings.Split(m.ResultCodes[code], "\n")
+}
+
 // HasEcho return true if method echoed some data
 func (m *Method) HasEcho() bool {
 	if m == nil {
@@ -246,6 +880,42 @@ func (m *Method) HasEcho() bool {
 	return m.ResultEcho != nil
 }
 
+// HasStderr return true if method prints some data to stderr
+func (m *Method) HasStderr() bool {
+	if m == nil {
+		return false
+	}
+
+	return len(m.Stderr) != 0
+}
+
+// HasFiles return true if method creates or modifies files
+func (m *Method) HasFiles() bool {
+	if m == nil {
+		return false
+	}
+
+	return len(m.Files) != 0
+}
+
+// HasReads return true if method reads global or environment variables
+func (m *Method) HasReads() bool {
+	if m == nil {
+		return false
+	}
+
+	return len(m.Reads) != 0
+}
+
+// HasModifies return true if method modifies global variables
+func (m *Method) HasModifies() bool {
+	if m == nil {
+		return false
+	}
+
+	return len(m.Modifies) != 0
+}
+
 // HasExample return true if method has code usage example
 func (m *Method) HasExample() bool {
 	if m == nil {
@@ -255,6 +925,60 @@ func (m *Method) HasExample() bool {
 	return m.Example != nil
 }
 
+// HasCalls return true if method calls other documented methods
+func (m *Method) HasCalls() bool {
+	if m == nil {
+		return false
+	}
+
+	return len(m.Calls) != 0
+}
+
+// HasCallers return true if method called by other documented methods
+func (m *Method) HasCallers() bool {
+	if m == nil {
+		return false
+	}
+
+	return len(m.CalledBy) != 0
+}
+
+// Signature returns method call signature
+func (m *Method) Signature() string {
+	if m == nil {
+		return ""
+	}
+
+	result := m.Name
+
+	for _, a := range m.Arguments {
+		name := a.Name
+
+		if name == "" {
+			name = "arg" + a.Index
+		}
+
+		if a.IsMultiple() {
+			if a.Name == "" {
+				name = "args"
+			}
+
+			name += "…"
+		}
+
+		switch {
+		case a.HasDefault():
+			name = "[" + name + "=" + a.Default + "]"
+		case a.IsOptional:
+			name = "[" + name + "]"
+		}
+
+		result += " " + name
+	}
+
+	return result
+}
+
 // UnitedDesc return united description string
 func (m *Method) UnitedDesc() string {
 	if m == nil {
@@ -264,22 +988,94 @@ func (m *Method) UnitedDesc() string {
 	return mergeDesc(m.Desc)
 }
 
+// Markup returns description with markup
+func (m *Method) Markup() Markup {
+	if m == nil {
+		return nil
+	}
+
+	return ParseMarkup(m.Desc)
+}
+
+// IsDeprecated return true if entity marked as deprecated
+func (t *Tags) IsDeprecated() bool {
+	if t == nil {
+		return false
+	}
+
+	return t.Deprecated != nil
+}
+
+// HasSee return true if entity has references to related entities
+func (t *Tags) HasSee() bool {
+	if t == nil {
+		return false
+	}
+
+	return len(t.See) != 0
+}
+
+// HasTags return true if entity has info from any tag
+func (t *Tags) HasTags() bool {
+	if t == nil {
+		return false
+	}
+
+	return t.Deprecated != nil || t.Since != "" || len(t.See) != 0 || t.Author != ""
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // getTypeName returns variable type name
 func getTypeName(t VariableType, mod int) string {
-	mod = mathutil.Between(mod, 0, 4)
-
-	switch t {
-	case VAR_TYPE_STRING:
-		return typeNameString[mod]
-	case VAR_TYPE_NUMBER:
-		return typeNameNumber[mod]
-	case VAR_TYPE_BOOLEAN:
-		return typeNameBoolean[mod]
-	default:
+	info := GetTypeInfo(t)
+
+	if info == nil {
+		return ""
+	}
+
+	names := []string{
+		info.Name, strings.ToLower(info.Name), strings.ToUpper(info.Name),
+		strings.ToUpper(info.Name[:1]), strings.ToLower(info.Name[:1]),
+	}
+
+	return names[mathutil.Between(mod, 0, 4)]
+}
+
+// getTypeColor returns color of variable type
+func getTypeColor(t VariableType) string {
+	info := GetTypeInfo(t)
+
+	if info == nil {
 		return ""
 	}
+
+	return info.Color
+}
+
+// findSection searches section with given path in sections tree
+func findSection(sections []*Section, path string) *Section {
+	for _, s := range sections {
+		if strings.EqualFold(s.Path, path) {
+			return s
+		}
+
+		if found := findSection(s.Sections, path); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// appendSections appends sections from tree to slice in depth-first order
+func appendSections(result []*Section, sections []*Section) []*Section {
+	for _, s := range sections {
+		result = append(result, s)
+		result = appendSections(result, s.Sections)
+	}
+
+	return result
 }
 
 // mergeDesc merges description lines to one string
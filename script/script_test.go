@@ -56,23 +56,62 @@ func (s *ScriptSuite) TestNil(c *C) {
 	c.Assert(d.HasConstants(), Equals, false)
 	c.Assert(d.HasVariables(), Equals, false)
 	c.Assert(d.HasMethods(), Equals, false)
+	c.Assert(d.FindMethod("test"), IsNil)
+	c.Assert(d.FindVariable("test"), IsNil)
+	c.Assert(d.HasIncludes(), Equals, false)
+	c.Assert(d.HasSections(), Equals, false)
+	c.Assert(d.FindSection("test"), IsNil)
+	c.Assert(d.Groups(), IsNil)
+	c.Assert(d.OriginOf("test.sh"), Equals, "")
+
+	var ds *DocumentSet
+	var i *Index
+	var ie *IndexEntry
+
+	c.Assert(i.HasScripts(), Equals, false)
+	c.Assert(i.HasUndocumented(), Equals, false)
+	c.Assert(ie.Summary(), Equals, "")
+
+	c.Assert(ds.Root(), IsNil)
+	c.Assert(ds.Find("test.sh"), IsNil)
+	c.Assert(ds.Merge(), IsNil)
 
 	c.Assert(a.TypeName(0), Equals, "")
+	c.Assert(a.TypeColor(), Equals, "")
+	c.Assert(a.IsVariadic(), Equals, false)
+	c.Assert(a.DescLines(), IsNil)
+	c.Assert(a.IsMultiple(), Equals, false)
+	c.Assert(a.HasPosition(1), Equals, false)
 	c.Assert(a.IsString(), Equals, false)
 	c.Assert(a.IsNumber(), Equals, false)
 	c.Assert(a.IsBoolean(), Equals, false)
 	c.Assert(a.IsUnknown(), Equals, false)
 
 	c.Assert(v.TypeName(0), Equals, "")
+	c.Assert(v.TypeColor(), Equals, "")
 	c.Assert(v.IsString(), Equals, false)
 	c.Assert(v.IsNumber(), Equals, false)
 	c.Assert(v.IsBoolean(), Equals, false)
+	c.Assert(v.IsArray(), Equals, false)
+	c.Assert(v.IsMap(), Equals, false)
 	c.Assert(v.IsUnknown(), Equals, false)
+	c.Assert(v.HasElements(), Equals, false)
+	c.Assert(v.IsMultiline(), Equals, false)
 	c.Assert(v.UnitedDesc(), Equals, "")
 
 	c.Assert(m.HasArguments(), Equals, false)
+	c.Assert(m.HasCodes(), Equals, false)
+	c.Assert(m.Codes(), IsNil)
+	c.Assert(m.CodeLines(0), IsNil)
 	c.Assert(m.HasEcho(), Equals, false)
+	c.Assert(m.HasStderr(), Equals, false)
+	c.Assert(m.HasFiles(), Equals, false)
+	c.Assert(m.HasReads(), Equals, false)
+	c.Assert(m.HasModifies(), Equals, false)
 	c.Assert(m.HasExample(), Equals, false)
+	c.Assert(m.HasCalls(), Equals, false)
+	c.Assert(m.HasCallers(), Equals, false)
+	c.Assert(m.Signature(), Equals, "")
 	c.Assert(m.UnitedDesc(), Equals, "")
 }
 
@@ -90,11 +129,11 @@ func (s *ScriptSuite) TestHelpers(c *C) {
 	c.Assert(d.HasVariables(), Equals, true)
 	c.Assert(d.HasMethods(), Equals, true)
 
-	a1 := &Argument{"1", "A1", VAR_TYPE_UNKNOWN, false, false}
-	a2 := &Argument{"2", "A2", VAR_TYPE_STRING, false, false}
-	a3 := &Argument{"3", "A3", VAR_TYPE_NUMBER, false, false}
-	a4 := &Argument{"4", "A4", VAR_TYPE_BOOLEAN, false, false}
-	a5 := &Argument{"*", "A5", VAR_TYPE_UNKNOWN, true, true}
+	a1 := &Argument{Index: "1", From: 1, To: 1, Desc: "A1", Type: VAR_TYPE_UNKNOWN}
+	a2 := &Argument{Index: "2", From: 2, To: 2, Name: "name", Desc: "A2", Type: VAR_TYPE_STRING}
+	a3 := &Argument{Index: "3", From: 3, To: 3, Desc: "A3", Type: VAR_TYPE_NUMBER}
+	a4 := &Argument{Index: "4", From: 4, To: 4, Desc: "A4", Type: VAR_TYPE_BOOLEAN}
+	a5 := &Argument{Index: "*", From: 1, To: ARG_INDEX_ANY, Desc: "A5", Type: VAR_TYPE_UNKNOWN, IsOptional: true}
 
 	c.Assert(a1.TypeName(VAR_MOD_DEFAULT), Equals, "")
 	c.Assert(a2.TypeName(VAR_MOD_DEFAULT), Equals, "String")
@@ -104,13 +143,41 @@ func (s *ScriptSuite) TestHelpers(c *C) {
 
 	c.Assert(a1.IsUnknown(), Equals, true)
 	c.Assert(a2.IsString(), Equals, true)
+
+	min, max := 1, 10
+
+	c.Assert(a1.HasDefault(), Equals, false)
+	c.Assert(a1.HasRange(), Equals, false)
+	c.Assert(a1.HasEnum(), Equals, false)
+	c.Assert(a1.Range(), Equals, "")
+	c.Assert(a1.Constraints(), Equals, "")
+
+	a3.Min, a3.Max, a3.Default = &min, &max, "5"
+
+	c.Assert(a3.HasDefault(), Equals, true)
+	c.Assert(a3.HasRange(), Equals, true)
+	c.Assert(a3.Range(), Equals, "1..10")
+	c.Assert(a3.Constraints(), Equals, "1..10")
+
+	a3.Min = nil
+
+	c.Assert(a3.Range(), Equals, "*..10")
+
+	a3.Min, a3.Max = &min, nil
+
+	c.Assert(a3.Range(), Equals, "1..*")
+
+	a2.Enum = []string{"a", "b"}
+
+	c.Assert(a2.HasEnum(), Equals, true)
+	c.Assert(a2.Constraints(), Equals, "a|b")
 	c.Assert(a3.IsNumber(), Equals, true)
 	c.Assert(a4.IsBoolean(), Equals, true)
 
-	v1 := &Variable{"1", []string{"V1", "", "D"}, VAR_TYPE_UNKNOWN, "v1", 1}
-	v2 := &Variable{"2", []string{"V2"}, VAR_TYPE_STRING, "v2", 2}
-	v3 := &Variable{"3", []string{"V3"}, VAR_TYPE_NUMBER, "v3", 3}
-	v4 := &Variable{"4", []string{"V4"}, VAR_TYPE_BOOLEAN, "v4", 4}
+	v1 := &Variable{Name: "1", Desc: []string{"V1", "", "D"}, Type: VAR_TYPE_UNKNOWN, Value: "v1", Line: 1}
+	v2 := &Variable{Name: "2", Desc: []string{"V2"}, Type: VAR_TYPE_STRING, Value: "v2", Line: 2}
+	v3 := &Variable{Name: "3", Desc: []string{"V3"}, Type: VAR_TYPE_NUMBER, Value: "v3", Line: 3}
+	v4 := &Variable{Name: "4", Desc: []string{"V4"}, Type: VAR_TYPE_BOOLEAN, Value: "v4", Line: 4}
 
 	c.Assert(v1.TypeName(VAR_MOD_DEFAULT), Equals, "")
 	c.Assert(v2.TypeName(VAR_MOD_DEFAULT), Equals, "String")
@@ -124,20 +191,408 @@ func (s *ScriptSuite) TestHelpers(c *C) {
 
 	c.Assert(v1.UnitedDesc(), Equals, "V1 D")
 
+	v5 := &Variable{Name: "5", Type: VAR_TYPE_ARRAY, Elements: []*Element{{Value: "a"}}}
+	v6 := &Variable{Name: "6", Type: VAR_TYPE_MAP, Elements: []*Element{{Key: "k", Value: "v"}}}
+
+	c.Assert(v5.TypeName(VAR_MOD_DEFAULT), Equals, "Array")
+	c.Assert(v6.TypeName(VAR_MOD_DEFAULT), Equals, "Map")
+	c.Assert(v5.IsArray(), Equals, true)
+	c.Assert(v5.IsMap(), Equals, false)
+	c.Assert(v6.IsMap(), Equals, true)
+	c.Assert(v5.HasElements(), Equals, true)
+	c.Assert(v1.HasElements(), Equals, false)
+
+	v7 := &Variable{Name: "7", Type: VAR_TYPE_STRING, Value: "\"a\nb\""}
+
+	c.Assert(v1.IsMultiline(), Equals, false)
+	c.Assert(v7.IsMultiline(), Equals, true)
+
 	m := &Method{
 		Name: "m1",
 		Desc: []string{"M1", "", "D"},
 		Arguments: []*Argument{
-			&Argument{"1", "A1", VAR_TYPE_UNKNOWN, false, false},
+			&Argument{Index: "1", Desc: "A1", Type: VAR_TYPE_UNKNOWN},
 		},
-		ResultCode: true,
-		ResultEcho: &Variable{"1", []string{"V1"}, VAR_TYPE_STRING, "v1", 1},
-		Example:    []string{"example"},
-		Line:       15,
+		ResultCodes: map[int]string{2: "error", 0: "ok"},
+		ResultEcho:  &Variable{Name: "1", Desc: []string{"V1"}, Type: VAR_TYPE_STRING, Value: "v1", Line: 1},
+		Stderr:      []string{"Error message"},
+		Files:       []*File{{Path: "/tmp/file"}},
+		Reads:       []*VariableRef{{Name: "HOME"}},
+		Example:     []string{"example"},
+		Line:        15,
+		Calls:       []string{"m2"},
 	}
 
 	c.Assert(m.HasArguments(), Equals, true)
+	c.Assert(m.HasCodes(), Equals, true)
+	c.Assert(m.Codes(), DeepEquals, []int{0, 2})
+	c.Assert(m.CodeLines(2), HasLen, 1)
+	c.Assert(m.CodeLines(5), IsNil)
 	c.Assert(m.HasEcho(), Equals, true)
+	c.Assert(m.HasStderr(), Equals, true)
+	c.Assert(m.HasFiles(), Equals, true)
+	c.Assert(m.HasReads(), Equals, true)
+	c.Assert(m.HasModifies(), Equals, false)
 	c.Assert(m.HasExample(), Equals, true)
+	c.Assert(m.HasCalls(), Equals, true)
+	c.Assert(m.HasCallers(), Equals, false)
 	c.Assert(m.UnitedDesc(), Equals, "M1 D")
+	c.Assert(m.Signature(), Equals, "m1 arg1")
+
+	m.Arguments = append(m.Arguments, a2, a5)
+
+	c.Assert(m.Signature(), Equals, "m1 arg1 name [args…]")
+
+	c.Assert(a1.IsVariadic(), Equals, false)
+	c.Assert(a1.IsMultiple(), Equals, false)
+	c.Assert(a1.HasPosition(1), Equals, true)
+	c.Assert(a1.HasPosition(2), Equals, false)
+	c.Assert(a5.IsVariadic(), Equals, true)
+	c.Assert(a5.IsMultiple(), Equals, true)
+	c.Assert(a5.HasPosition(10), Equals, true)
+
+	a6 := &Argument{Index: "2..3", From: 2, To: 3, Name: "pair", Desc: "A6"}
+
+	c.Assert(a6.IsVariadic(), Equals, false)
+	c.Assert(a6.IsMultiple(), Equals, true)
+	c.Assert(a6.HasPosition(1), Equals, false)
+	c.Assert(a6.HasPosition(3), Equals, true)
+	c.Assert(a6.HasPosition(4), Equals, false)
+
+	m.Arguments = []*Argument{a1, a6}
+
+	c.Assert(m.Signature(), Equals, "m1 arg1 pair…")
+
+	d.Methods = []*Method{m}
+
+	c.Assert(d.FindMethod("m1"), Equals, m)
+	c.Assert(d.FindMethod("m2"), IsNil)
+
+	d.Constants = []*Variable{v1}
+	d.Variables = []*Variable{v2}
+
+	c.Assert(d.FindVariable("1"), Equals, v1)
+	c.Assert(d.FindVariable("2"), Equals, v2)
+	c.Assert(d.FindVariable("3"), IsNil)
+
+	c.Assert(d.DescOf(&VariableRef{Name: "1", Desc: "Own"}), Equals, "Own")
+	c.Assert(d.DescOf(&VariableRef{Name: "1"}), Equals, v1.UnitedDesc())
+	c.Assert(d.DescOf(&VariableRef{Name: "3"}), Equals, "")
+	c.Assert(d.DescOf(nil), Equals, "")
+}
+
+func (s *ScriptSuite) TestTags(c *C) {
+	var t *Tags
+
+	c.Assert(t.IsDeprecated(), Equals, false)
+	c.Assert(t.HasSee(), Equals, false)
+	c.Assert(t.HasTags(), Equals, false)
+
+	m := &Method{Name: "m1"}
+
+	c.Assert(m.IsDeprecated(), Equals, false)
+	c.Assert(m.HasTags(), Equals, false)
+
+	m.Since = "1.0.0"
+
+	c.Assert(m.HasTags(), Equals, true)
+
+	v := &Variable{Name: "V1", Tags: Tags{
+		Deprecated: &Deprecation{Replacement: "V2"},
+		See:        []string{"V2"},
+	}}
+
+	c.Assert(v.IsDeprecated(), Equals, true)
+	c.Assert(v.HasSee(), Equals, true)
+	c.Assert(v.HasTags(), Equals, true)
+}
+
+func (s *ScriptSuite) TestTypes(c *C) {
+	c.Assert(FindType("Path"), Equals, VAR_TYPE_PATH)
+	c.Assert(FindType("path"), Equals, VAR_TYPE_UNKNOWN)
+	c.Assert(GetTypeInfo(VAR_TYPE_UNKNOWN), IsNil)
+	c.Assert(GetTypeInfo(VAR_TYPE_URL).Name, Equals, "URL")
+	c.Assert(getTypeName(VAR_TYPE_URL, VAR_MOD_LOWERCASE_SHORT), Equals, "u")
+	c.Assert(getTypeColor(VAR_TYPE_STRING), Equals, "#5598E2")
+
+	t, err := RegisterType("Email", "#aa00aa")
+
+	c.Assert(err, IsNil)
+	c.Assert(t, Equals, Types()[len(Types())-1].Type)
+	c.Assert(FindType("Email"), Equals, t)
+	c.Assert(GetTypeInfo(t).Color, Equals, "#AA00AA")
+	c.Assert(getTypeName(t, 2), Equals, "EMAIL")
+
+	t, err = RegisterType("Email", "")
+
+	c.Assert(err, IsNil)
+	c.Assert(GetTypeInfo(t).Color, Equals, "#AA00AA")
+
+	t, err = RegisterType("String", "#000000")
+
+	c.Assert(err, IsNil)
+	c.Assert(t, Equals, VAR_TYPE_STRING)
+	c.Assert(getTypeColor(VAR_TYPE_STRING), Equals, "#000000")
+
+	GetTypeInfo(VAR_TYPE_STRING).Color = "#5598E2"
+	GetTypeInfo(VAR_TYPE_STRING).Term = "b"
+
+	t, err = RegisterType("Phone", "")
+
+	c.Assert(err, IsNil)
+	c.Assert(getTypeColor(t), Equals, DEFAULT_TYPE_COLOR)
+
+	_, err = RegisterType("email", "#AA00AA")
+	c.Assert(err, NotNil)
+	_, err = RegisterType("Email", "red")
+	c.Assert(err, NotNil)
+}
+
+func (s *ScriptSuite) TestSections(c *C) {
+	var sn *Section
+
+	c.Assert(sn.HasConstants(), Equals, false)
+	c.Assert(sn.HasVariables(), Equals, false)
+	c.Assert(sn.HasMethods(), Equals, false)
+	c.Assert(sn.IsEmpty(), Equals, true)
+	c.Assert(sn.Contains("test"), Equals, false)
+	c.Assert(sn.Level(), Equals, 0)
+	c.Assert(sn.UnitedDesc(), Equals, "")
+
+	c1 := &Variable{Name: "C1", Section: "Net"}
+	v1 := &Variable{Name: "v1"}
+	m1 := &Method{Name: "m1", Section: "Net/HTTP"}
+	m2 := &Method{Name: "m2"}
+
+	s2 := &Section{Name: "HTTP", Path: "Net/HTTP", Methods: []*Method{m1}}
+	s1 := &Section{
+		Name: "Net", Path: "Net", Desc: []string{"Network", "helpers"},
+		Constants: []*Variable{c1}, Sections: []*Section{s2},
+	}
+
+	d := &Document{
+		Constants: []*Variable{c1},
+		Variables: []*Variable{v1},
+		Methods:   []*Method{m1, m2},
+		Sections:  []*Section{s1},
+	}
+
+	c.Assert(d.HasSections(), Equals, true)
+	c.Assert(d.FindSection(""), IsNil)
+	c.Assert(d.FindSection("net/http"), Equals, s2)
+	c.Assert(d.FindSection("Strings"), IsNil)
+
+	c.Assert(s1.HasConstants(), Equals, true)
+	c.Assert(s1.HasVariables(), Equals, false)
+	c.Assert(s1.HasMethods(), Equals, false)
+	c.Assert(s1.IsEmpty(), Equals, false)
+	c.Assert(s1.Contains("Net"), Equals, true)
+	c.Assert(s1.Contains("net/HTTP"), Equals, true)
+	c.Assert(s1.Contains("Network"), Equals, false)
+	c.Assert(s1.Contains(""), Equals, false)
+	c.Assert(s1.UnitedDesc(), Equals, "Network helpers")
+	c.Assert(s1.Level(), Equals, 0)
+	c.Assert(s2.Level(), Equals, 1)
+	c.Assert(s2.Contains("Net"), Equals, false)
+
+	groups := d.Groups()
+
+	c.Assert(groups, HasLen, 3)
+	c.Assert(groups[0].Name, Equals, "")
+	c.Assert(groups[0].Constants, HasLen, 0)
+	c.Assert(groups[0].Variables, DeepEquals, []*Variable{v1})
+	c.Assert(groups[0].Methods, DeepEquals, []*Method{m2})
+	c.Assert(groups[1], Equals, s1)
+	c.Assert(groups[2], Equals, s2)
+
+	d = &Document{Methods: []*Method{m2}}
+
+	c.Assert(d.Groups(), HasLen, 1)
+}
+
+func (s *ScriptSuite) TestMarkup(c *C) {
+	m := ParseMarkup([]string{
+		"First line",
+		"  with `code` inside",
+		"",
+		"- item 1",
+		"  continues",
+		"* item 2",
+		"1. first",
+		"2) second",
+		"",
+		"    echo 1",
+		"",
+		"    echo 2",
+		"Text with ` backtick",
+		"```bash",
+		"  echo 3",
+		"```",
+	})
+
+	c.Assert(m, HasLen, 6)
+	c.Assert(m.IsEmpty(), Equals, false)
+
+	c.Assert(m[0].IsParagraph(), Equals, true)
+	c.Assert(m[0].Text(), HasLen, 3)
+	c.Assert(m[0].Text()[1], DeepEquals, &Span{Text: "code", IsCode: true})
+	c.Assert(m[0].Text().String(), Equals, "First line with code inside")
+
+	c.Assert(m[1].IsList(), Equals, true)
+	c.Assert(m[1].Items, HasLen, 2)
+	c.Assert(m[1].Items[0].String(), Equals, "item 1 continues")
+	c.Assert(m[1].Items[1].String(), Equals, "item 2")
+
+	c.Assert(m[2].IsNumbered(), Equals, true)
+	c.Assert(m[2].Items, HasLen, 2)
+	c.Assert(m[2].Items[1].String(), Equals, "second")
+
+	c.Assert(m[3].IsCode(), Equals, true)
+	c.Assert(m[3].Code, DeepEquals, []string{"echo 1", "", "echo 2"})
+
+	c.Assert(m[4].Text().String(), Equals, "Text with ` backtick")
+
+	c.Assert(m[5].IsCode(), Equals, true)
+	c.Assert(m[5].Code, DeepEquals, []string{"  echo 3"})
+
+	c.Assert(m.Head().String(), Equals, "First line with code inside")
+	c.Assert(m.Tail(), HasLen, 5)
+	c.Assert(m[1:].Head(), IsNil)
+	c.Assert(m[1:].Tail(), HasLen, 5)
+	c.Assert(m[:1].Tail(), IsNil)
+
+	c.Assert(ParseMarkup([]string{"```", "code"})[0].Code, DeepEquals, []string{"code"})
+
+	var e Markup
+	var b *Block
+
+	c.Assert(e.IsEmpty(), Equals, true)
+	c.Assert(e.Head(), IsNil)
+	c.Assert(e.Tail(), IsNil)
+	c.Assert(b.IsParagraph(), Equals, false)
+	c.Assert(b.IsList(), Equals, false)
+	c.Assert(b.IsNumbered(), Equals, false)
+	c.Assert(b.IsCode(), Equals, false)
+	c.Assert(b.Text(), IsNil)
+	c.Assert(getIndent("\t  x"), Equals, 6)
+	c.Assert(trimIndent("\tx", 4), Equals, "x")
+
+	var d *Document
+	var v *Variable
+	var mt *Method
+	var sn *Section
+
+	c.Assert(d.AboutMarkup(), IsNil)
+	c.Assert(v.Markup(), IsNil)
+	c.Assert(mt.Markup(), IsNil)
+	c.Assert(sn.Markup(), IsNil)
+
+	mt = &Method{Desc: []string{"Method", "", "- item"}}
+
+	c.Assert(mt.Markup(), HasLen, 2)
+}
+
+func (s *ScriptSuite) TestLinks(c *C) {
+	doc := &Document{
+		File:      "/opt/main.sh",
+		Constants: []*Variable{{Name: "DIR", File: "/opt/main.sh", Line: 3}},
+		Methods:   []*Method{{Name: "net::check", File: "/opt/lib/net.sh", Line: 8}},
+	}
+
+	t := parseText("See {@link net::check()} and {@link ${DIR}}, `$DIR` or `ls -la`")
+
+	c.Assert(t, HasLen, 8)
+	c.Assert(t[1], DeepEquals, &Span{Text: "net::check()", IsCode: true, Ref: "net::check()"})
+	c.Assert(t[3], DeepEquals, &Span{Text: "${DIR}", IsCode: true, Ref: "${DIR}"})
+	c.Assert(t[5].Ref, Equals, "")
+	c.Assert(t[7].Ref, Equals, "")
+
+	c.Assert(getRefName("$DIR"), Equals, "DIR")
+	c.Assert(getRefName("${DIR}"), Equals, "DIR")
+	c.Assert(getRefName("check()"), Equals, "check")
+	c.Assert(getRefName("ls -la"), Equals, "")
+
+	c.Assert(doc.LinkOf("DIR"), Equals, "3")
+	c.Assert(doc.LinkOf("net::check"), Equals, "lib-net-sh-8")
+	c.Assert(doc.LinkOf("unknown"), Equals, "")
+	c.Assert(doc.LinkOf("ls -la"), Equals, "")
+
+	spans := doc.MarkupOf([]string{
+		"See {@link net::check()} and {@link unknown}, `$DIR` or `ls -la`",
+	}).Spans()
+
+	c.Assert(spans, HasLen, 8)
+	c.Assert(spans[1].Link, Equals, "lib-net-sh-8")
+	c.Assert(spans[3].Ref, Equals, "unknown")
+	c.Assert(spans[3].Link, Equals, "")
+	c.Assert(spans[5].Ref, Equals, "$DIR")
+	c.Assert(spans[5].Link, Equals, "3")
+	c.Assert(spans[7].Ref, Equals, "")
+	c.Assert(spans[7].Link, Equals, "")
+
+	doc.About = []string{"Uses `DIR`"}
+
+	c.Assert(doc.AboutMarkup().Spans()[1].Link, Equals, "3")
+
+	var d *Document
+
+	c.Assert(d.MarkupOf([]string{"Test"}), IsNil)
+	c.Assert(d.LinkOf("DIR"), Equals, "")
+}
+
+func (s *ScriptSuite) TestDocumentSet(c *C) {
+	d1 := &Document{
+		Title:    "main.sh",
+		File:     "/opt/main.sh",
+		Methods:  []*Method{{Name: "m1", File: "/opt/main.sh"}},
+		Includes: []*Include{{Path: "lib/net.sh", File: "/opt/lib/net.sh", Line: 3}},
+	}
+
+	d2 := &Document{
+		Title:     "net.sh",
+		File:      "/opt/lib/net.sh",
+		Constants: []*Variable{{Name: "C1", File: "/opt/lib/net.sh"}},
+		Methods:   []*Method{{Name: "m2", File: "/opt/lib/net.sh"}},
+	}
+
+	ds := &DocumentSet{Documents: []*Document{d1, d2}}
+
+	c.Assert(ds.Root(), Equals, d1)
+	c.Assert(ds.Find("/opt/lib/net.sh"), Equals, d2)
+	c.Assert(ds.Find("/opt/unknown.sh"), IsNil)
+	c.Assert(d1.HasIncludes(), Equals, true)
+	c.Assert(d2.HasIncludes(), Equals, false)
+
+	doc := ds.Merge()
+
+	c.Assert(doc.Title, Equals, "main.sh")
+	c.Assert(doc.File, Equals, "/opt/main.sh")
+	c.Assert(doc.Constants, HasLen, 1)
+	c.Assert(doc.Methods, HasLen, 2)
+	c.Assert(doc.FindMethod("m2"), Equals, d2.Methods[0])
+	c.Assert(doc.OriginOf("/opt/main.sh"), Equals, "")
+	c.Assert(doc.OriginOf("/opt/lib/net.sh"), Equals, "lib/net.sh")
+	c.Assert(doc.AnchorOf("/opt/main.sh", 10), Equals, "10")
+	c.Assert(doc.AnchorOf("/opt/lib/net.sh", 8), Equals, "lib-net-sh-8")
+
+	c.Assert((&DocumentSet{}).Merge(), IsNil)
+}
+
+func (s *ScriptSuite) TestIndex(c *C) {
+	i := &Index{
+		Title: "scripts",
+		Scripts: []*IndexEntry{
+			{Path: "main.sh", Document: &Document{About: []string{"Main script", "Details"}}},
+			{Path: "lib/net.sh", Document: &Document{}},
+		},
+	}
+
+	c.Assert(i.HasScripts(), Equals, true)
+	c.Assert(i.HasUndocumented(), Equals, false)
+	c.Assert(i.Scripts[0].Summary(), Equals, "Main script")
+	c.Assert(i.Scripts[1].Summary(), Equals, "")
+
+	i.Undocumented = []string{"bin/tool"}
+
+	c.Assert(i.HasUndocumented(), Equals, true)
 }
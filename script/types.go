@@ -0,0 +1,114 @@
+package script
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// TypeInfo contains info about variable type
+type TypeInfo struct {
+	Type  VariableType // Type
+	Name  string       // Name used in type markers
+	Color string       // Color in hex format (#RRGGBB)
+	Term  string       // Color tag for terminal output
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_TYPE_COLOR is default color of custom types
+const DEFAULT_TYPE_COLOR = "#999999"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	typeNameRegExp  = regexp.MustCompile(`^[A-Z][a-zA-Z]{1,}$`)
+	typeColorRegExp = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// types contains info about all registered types
+var types = []*TypeInfo{
+	{VAR_TYPE_STRING, "String", "#5598E2", "b"},
+	{VAR_TYPE_NUMBER, "Number", "#DEAF57", "y"},
+	{VAR_TYPE_BOOLEAN, "Boolean", "#50C449", "g"},
+	{VAR_TYPE_ARRAY, "Array", "#B769D6", "m"},
+	{VAR_TYPE_MAP, "Map", "#3FB5B0", "c"},
+	{VAR_TYPE_PATH, "Path", "#E07B53", "#173"},
+	{VAR_TYPE_FILE, "File", "#D9705A", "#167"},
+	{VAR_TYPE_DIR, "Dir", "#A1887F", "#137"},
+	{VAR_TYPE_URL, "URL", "#5C6BC0", "#61"},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RegisterType registers custom type or changes color of already registered
+// type
+func RegisterType(name, color string) (VariableType, error) {
+	switch {
+	case !typeNameRegExp.MatchString(name):
+		return VAR_TYPE_UNKNOWN, fmt.Errorf("Invalid type name %q", name)
+	case color != "" && !typeColorRegExp.MatchString(color):
+		return VAR_TYPE_UNKNOWN, fmt.Errorf("Invalid color %q for type %q", color, name)
+	}
+
+	color = strings.ToUpper(color)
+	info := GetTypeInfo(FindType(name))
+
+	if info != nil {
+		if color != "" {
+			info.Color, info.Term = color, color
+		}
+
+		return info.Type, nil
+	}
+
+	last := types[len(types)-1].Type
+
+	if last == 255 {
+		return VAR_TYPE_UNKNOWN, fmt.Errorf("Can't register type %q: too many types", name)
+	}
+
+	if color == "" {
+		color = DEFAULT_TYPE_COLOR
+	}
+
+	types = append(types, &TypeInfo{last + 1, name, color, color})
+
+	return last + 1, nil
+}
+
+// FindType returns type with given name
+func FindType(name string) VariableType {
+	for _, info := range types {
+		if info.Name == name {
+			return info.Type
+		}
+	}
+
+	return VAR_TYPE_UNKNOWN
+}
+
+// GetTypeInfo returns info about given type
+func GetTypeInfo(t VariableType) *TypeInfo {
+	for _, info := range types {
+		if info.Type == t {
+			return info
+		}
+	}
+
+	return nil
+}
+
+// Types returns info about all registered types
+func Types() []*TypeInfo {
+	return types
+}
@@ -18,27 +18,40 @@
       p,div { position:relative }
       div.doc { display:block; font-size:.9em; margin-left:auto; margin-right:auto; padding-top:32px; width:800px }
       div.toc { margin:0; padding-top:8px }
+      div.toc.section { color:#888; font-size:.9em; padding-top:16px }
+      h3.section { font-size:1.2em; padding-top:24px }
+      div.section-desc { color:#666 }
+      div.desc,div.variable.desc { color:#444 }
+      div.desc p,div.desc ul,div.desc ol,div.desc pre { margin:8px 0 }
+      div.desc pre { background-color:#f5f5f5; border:1px solid #CCC; border-radius:4px; font-size:.9em; padding:8px 16px; white-space:pre-wrap }
+      div.section-desc p,div.variable.desc p { margin:4px 0 }
+      code { background-color:#f5f5f5; border-radius:3px; font-size:.9em; padding:0 4px }
+      a.ref code { border-bottom:1px dotted #666 }
       div.entity { margin:0; padding-top:16px }
       div.method { margin:0; padding-top:48px }
       div.entity::before,div.toc::before,div.method::before { color:#AAA; content:attr(data-loc); font-size:.9em; margin-right:12px; margin-top:4px; position:absolute; right:100% }
       div.method-data { margin-left:24px }
       div.argument { padding-top:2px }
+      div.signature { color:#666; font-size:.9em; padding-top:16px }
       div.arguments,div.result,div.example { padding-top:16px }
       div.example-code { background-color:#f5f5f5; border:1px solid #CCC; border-radius:4px; color:#444; font-size:.9em; margin-top:8px; padding:16px; white-space:pre-wrap }
+      span.origin { color:#999; font-family:monospace; font-size:.8em }
+      a.deprecated { color:#999; text-decoration:line-through }
+      span.deprecated { background-color:#D9534F }
+      div.tags { color:#666; font-size:.9em; padding-top:4px }
+      div.tags a { border-bottom:1px dotted #666 }
       span.badge { border-radius:4px; color:#FFF; cursor:default; font-size:.6em; font-weight:700; padding:2px 4px; vertical-align:middle }
-      span.number { background-color:#DEAF57 }
-      span.string { background-color:#5598E2 }
-      span.boolean { background-color:#50C449 }
-      span.dot { font-weight:700; vertical-align:middle }
-      span.dot-number { color:#DEAF57 }
-      span.dot-string { color:#5598E2 }
-      span.dot-boolean { color:#50C449 }
       span.desc { color:#444 }
       span.variable { font-size:.9em }
       span.optional { background-color:#BBB }
+      span.constraints { color:#888; font-size:.9em }
       div.footer { color:#999; font-size:.9em; padding:64px 0 40px; text-align:center }
       div.footer a { border-bottom:1px solid #666; color:#666 }
       span.equals,span.title { color:#888 }
+      span.code,span.mono { white-space:pre-wrap }
+      ul.elements { margin:4px 0 0; padding-left:24px }
+      table.elements,table.codes { border-collapse:collapse; margin-top:4px }
+      table.elements td,table.codes td { border:1px solid #DDD; font-size:.9em; padding:2px 8px }
     </style>
   </head>
   <body>
@@ -47,7 +60,7 @@
       {{ if .HasAbout }}
       <h2>About</h2>
       <div>
-        {{ range .About }}<p>{{ . }}</p>{{ end }}
+        {{ template "markup" .AboutMarkup }}
       </div>
       {{ end }}
 
@@ -55,84 +68,161 @@
 
       {{ if .HasConstants }}
       <h3>Constants</h3>
+      {{ range .Groups }}{{ if .HasConstants }}{{ if .Name }}
+      <div class="toc section">{{ .Path }}</div>{{ end }}
       {{ range .Constants }}
-      <div data-loc="{{ .Line }}" class="toc"><a class="mono" href="#{{ .Line }}">{{ .Name }}</a> <span class="dot dot-{{ .TypeName 1 }}">•</span></div>
-      {{ end }}
+      <div data-loc="{{ .Line }}" class="toc"><a class="mono{{ if .IsDeprecated }} deprecated{{ end }}" href="#{{ $.AnchorOf .File .Line }}">{{ .Name }}</a> <span class="dot" style="color:{{ .TypeColor }}">•</span></div>
+      {{ end }}{{ end }}{{ end }}
       {{ end }}
 
       {{ if .HasVariables }}
       <h3>Global Variables</h3>
+      {{ range .Groups }}{{ if .HasVariables }}{{ if .Name }}
+      <div class="toc section">{{ .Path }}</div>{{ end }}
       {{ range .Variables }}
-      <div data-loc="{{ .Line }}" class="toc"><a class="mono" href="#{{ .Line }}">{{ .Name }}</a> <span class="dot dot-{{ .TypeName 1 }}">•</span></div>
-      {{ end }}
+      <div data-loc="{{ .Line }}" class="toc"><a class="mono{{ if .IsDeprecated }} deprecated{{ end }}" href="#{{ $.AnchorOf .File .Line }}">{{ .Name }}</a> <span class="dot" style="color:{{ .TypeColor }}">•</span></div>
+      {{ end }}{{ end }}{{ end }}
       {{ end }}
 
       {{ if .HasMethods }}
       <h3>Methods</h3>
+      {{ range .Groups }}{{ if .HasMethods }}{{ if .Name }}
+      <div class="toc section">{{ .Path }}</div>{{ end }}
       {{ range .Methods }}
-      <div data-loc="{{ .Line }}" class="toc"><a class="mono" href="#{{ .Line }}">{{ .Name }}</a></div>
-      {{ end }}
+      <div data-loc="{{ .Line }}" class="toc"><a class="mono{{ if .IsDeprecated }} deprecated{{ end }}" href="#{{ $.AnchorOf .File .Line }}">{{ .Name }}</a></div>
+      {{ end }}{{ end }}{{ end }}
       {{ end }}
 
       <!-- CONSTANTS -->
 
       {{ if .HasConstants }}
       <h2>Constants</h2>
+      {{ range .Groups }}{{ if .HasConstants }}{{ template "section" . }}{{ template "section-desc" ($.MarkupOf .Desc) }}
       {{ range .Constants }}
-      <div data-loc="{{ .Line }}" id="{{ .Line }}" class="entity">
+      <div data-loc="{{ .Line }}" id="{{ $.AnchorOf .File .Line }}" class="entity">
         <div>
-          <a class="mono" href="#{{ .Line }}">{{ .Name }}</a> <span class="equals">=</span> <span class="code">{{ .Value }}</span> <span class="badge {{ .TypeName 1 }}">{{ .TypeName 2 }}</span>
+          <a class="mono{{ if .IsDeprecated }} deprecated{{ end }}" href="#{{ $.AnchorOf .File .Line }}">{{ .Name }}</a> <span class="equals">=</span> <span class="code">{{ .Value }}</span> <span class="badge" style="background-color:{{ .TypeColor }}">{{ .TypeName 2 }}</span>{{ if .IsDeprecated }} <span class="badge deprecated">DEPRECATED</span>{{ end }}
         </div>
         <div>
-          <span class="variable desc">{{ .UnitedDesc }}</span>
-        </div>
+          <div class="variable desc">{{ template "markup" ($.MarkupOf .Desc) }}</div>
+        </div>{{ with .Deprecated }}
+        <div class="tags">Deprecated{{ with .Replacement }}, use <span class="mono">{{ . }}</span> instead{{ end }}{{ with .Note }}: {{ . }}{{ end }}</div>{{ end }}
+        {{- template "tags" . }}
+        {{ if .HasElements }}{{ if .IsMap }}
+        <table class="elements mono">
+          {{ range .Elements }}<tr><td>{{ .Key }}</td><td>{{ .Value }}</td></tr>{{ end }}
+        </table>
+        {{ else }}
+        <ul class="elements mono">
+          {{ range .Elements }}<li>{{ if .Key }}[{{ .Key }}] {{ end }}{{ .Value }}</li>{{ end }}
+        </ul>
+        {{ end }}{{ end }}
       </div>
-      {{ end }}
+      {{ end }}{{ end }}{{ end }}
       {{ end }}
 
       <!-- VARIABLES -->
 
       {{ if .HasVariables }}
       <h2>Global Variables</h2>
+      {{ range .Groups }}{{ if .HasVariables }}{{ template "section" . }}{{ template "section-desc" ($.MarkupOf .Desc) }}
       {{ range .Variables }}
-      <div data-loc="{{ .Line }}" id="{{ .Line }}" class="entity">
+      <div data-loc="{{ .Line }}" id="{{ $.AnchorOf .File .Line }}" class="entity">
         <div>
-          <a class="mono" href="#{{ .Line }}">{{ .Name }}</a> <span class="equals">=</span> <span class="mono">{{ .Value }}</span> <span class="badge {{ .TypeName 1 }}">{{ .TypeName 2 }}</span>
+          <a class="mono{{ if .IsDeprecated }} deprecated{{ end }}" href="#{{ $.AnchorOf .File .Line }}">{{ .Name }}</a> <span class="equals">=</span> <span class="mono">{{ .Value }}</span> <span class="badge" style="background-color:{{ .TypeColor }}">{{ .TypeName 2 }}</span>{{ if .IsDeprecated }} <span class="badge deprecated">DEPRECATED</span>{{ end }}
         </div>
         <div>
-          <span class="variable desc">{{ .UnitedDesc }}</span>
-        </div>
+          <div class="variable desc">{{ template "markup" ($.MarkupOf .Desc) }}</div>
+        </div>{{ with .Deprecated }}
+        <div class="tags">Deprecated{{ with .Replacement }}, use <span class="mono">{{ . }}</span> instead{{ end }}{{ with .Note }}: {{ . }}{{ end }}</div>{{ end }}
+        {{- template "tags" . }}
+        {{ if .HasElements }}{{ if .IsMap }}
+        <table class="elements mono">
+          {{ range .Elements }}<tr><td>{{ .Key }}</td><td>{{ .Value }}</td></tr>{{ end }}
+        </table>
+        {{ else }}
+        <ul class="elements mono">
+          {{ range .Elements }}<li>{{ if .Key }}[{{ .Key }}] {{ end }}{{ .Value }}</li>{{ end }}
+        </ul>
+        {{ end }}{{ end }}
       </div>
-      {{ end }}
+      {{ end }}{{ end }}{{ end }}
       {{ end }}
 
       <!-- METHODS -->
 
       {{ if .HasMethods }}
       <h2>Methods</h2>
+      {{ range .Groups }}{{ if .HasMethods }}{{ template "section" . }}{{ template "section-desc" ($.MarkupOf .Desc) }}
       {{ range .Methods }}
-      <div data-loc="{{ .Line }}" id="{{ .Line }}" class="method">
+      <div data-loc="{{ .Line }}" id="{{ $.AnchorOf .File .Line }}" class="method">
         <div>
-          <a class="mono" href="#{{ .Line }}">{{ .Name }}</a><span class="desc"> — {{ .UnitedDesc }}</span>
-        </div>
-        <div class="method-data">
+          <a class="mono{{ if .IsDeprecated }} deprecated{{ end }}" href="#{{ $.AnchorOf .File .Line }}">{{ .Name }}</a><span class="desc"> — {{ template "text" ($.MarkupOf .Desc).Head }}</span>{{ with $.OriginOf .File }} <span class="origin">{{ . }}</span>{{ end }}{{ if .IsDeprecated }} <span class="badge deprecated">DEPRECATED</span>{{ end }}
+        </div>{{ with .Deprecated }}
+        <div class="tags">Deprecated{{ with .Replacement }}, use {{ with $.FindMethod . }}<a class="mono" href="#{{ $.AnchorOf .File .Line }}">{{ .Name }}</a>{{ else }}<span class="mono">{{ . }}</span>{{ end }} instead{{ end }}{{ with .Note }}: {{ . }}{{ end }}</div>{{ end }}
+        {{- template "tags" . }}
+        <div class="method-data">{{ with ($.MarkupOf .Desc).Tail }}
+          <div class="desc">{{ template "markup" . }}</div>{{ end }}
           {{ if .HasArguments }}
+          <div class="signature mono">{{ .Signature }}</div>
           <div class="arguments">
             {{ range .Arguments }}
             <div class="argument">
-              <span class="variable title">{{ .Index }}.</span> <span class="variable desc">{{ .Desc }}</span> <span class="badge {{ .TypeName 1 }}">{{ .TypeName 2 }}</span> {{ if .IsOptional }}<span class="badge optional">OPTIONAL</span>{{ end }}
+              <span class="variable title">{{ .Index }}.</span> {{ with .Name }}<span class="variable mono">{{ . }}</span> {{ end }}<span class="variable desc">{{ range $i, $l := .DescLines }}{{ if $i }}<br/>{{ end }}{{ $l }}{{ end }}</span> <span class="badge" style="background-color:{{ .TypeColor }}">{{ .TypeName 2 }}</span>{{ with .Constraints }} <span class="constraints mono">{{ . }}</span>{{ end }} {{ if .HasDefault }}<span class="badge optional">DEFAULT: {{ .Default }}</span>{{ else if .IsOptional }}<span class="badge optional">OPTIONAL</span>{{ end }}
             </div>
             {{ end }}
           </div>
           {{ end }}
-          {{ if .ResultCode }}
+          {{ if .HasCodes }}
           <div class="result">
-            <span class="variable title">Code:</span> <span class="variable desc">0 - ok, 1 - not ok</span>
+            <span class="variable title">Code:</span>
+            <table class="codes">
+              {{ $m := . }}{{ range .Codes }}<tr><td class="mono">{{ . }}</td><td>{{ range $i, $l := $m.CodeLines . }}{{ if $i }}<br/>{{ end }}{{ $l }}{{ end }}</td></tr>{{ end }}
+            </table>
           </div>
           {{ end }}
           {{ if .HasEcho }}
           <div class="result">
-            <span class="variable title">Echo:</span> <span class="variable desc">{{ .ResultEcho.UnitedDesc }}</span> <span class="badge {{ .ResultEcho.TypeName 1 }}">{{ .ResultEcho.TypeName 2 }}</span>
+            <span class="variable title">Echo:</span> <span class="variable desc">{{ range $i, $l := .ResultEcho.Desc }}{{ if $i }}<br/>{{ end }}{{ $l }}{{ end }}</span> <span class="badge" style="background-color:{{ .ResultEcho.TypeColor }}">{{ .ResultEcho.TypeName 2 }}</span>
+          </div>
+          {{ end }}
+          {{ if .HasStderr }}
+          <div class="result">
+            <span class="variable title">Stderr:</span> <span class="variable desc">{{ range $i, $l := .Stderr }}{{ if $i }}<br/>{{ end }}{{ $l }}{{ end }}</span>
+          </div>
+          {{ end }}
+          {{ if .HasFiles }}
+          <div class="result">
+            <span class="variable title">Files:</span>
+            <ul class="elements">
+              {{ range .Files }}<li><span class="mono">{{ .Path }}</span>{{ with .Desc }} — {{ . }}{{ end }}</li>{{ end }}
+            </ul>
+          </div>
+          {{ end }}
+          {{ if .HasReads }}
+          <div class="result">
+            <span class="variable title">Reads:</span>
+            <ul class="elements">
+              {{ range .Reads }}<li>{{ with $.FindVariable .Name }}<a class="mono" href="#{{ $.AnchorOf .File .Line }}">{{ .Name }}</a>{{ else }}<span class="mono">{{ .Name }}</span>{{ end }}{{ with $.DescOf . }} — {{ . }}{{ end }}</li>{{ end }}
+            </ul>
+          </div>
+          {{ end }}
+          {{ if .HasModifies }}
+          <div class="result">
+            <span class="variable title">Modifies:</span>
+            <ul class="elements">
+              {{ range .Modifies }}<li>{{ with $.FindVariable .Name }}<a class="mono" href="#{{ $.AnchorOf .File .Line }}">{{ .Name }}</a>{{ else }}<span class="mono">{{ .Name }}</span>{{ end }}{{ with $.DescOf . }} — {{ . }}{{ end }}</li>{{ end }}
+            </ul>
+          </div>
+          {{ end }}
+          {{ if .HasCalls }}
+          <div class="result">
+            <span class="variable title">Calls:</span> <span class="variable desc mono">{{ range $i, $c := .Calls }}{{ if $i }}, {{ end }}{{ with $.FindMethod $c }}<a href="#{{ $.AnchorOf .File .Line }}">{{ .Name }}</a>{{ end }}{{ end }}</span>
+          </div>
+          {{ end }}
+          {{ if .HasCallers }}
+          <div class="result">
+            <span class="variable title">Called by:</span> <span class="variable desc mono">{{ range $i, $c := .CalledBy }}{{ if $i }}, {{ end }}{{ with $.FindMethod $c }}<a href="#{{ $.AnchorOf .File .Line }}">{{ .Name }}</a>{{ end }}{{ end }}</span>
           </div>
           {{ end }}
           {{ if .HasExample }}
@@ -143,7 +233,7 @@
           {{ end }}
         </div>
       </div>
-      {{ end }}
+      {{ end }}{{ end }}{{ end }}
       {{ end }}
     </div>
 
@@ -152,3 +242,14 @@
     <div class="footer">Generated with ❤ by <a href="https://kaos.sh/shdoc">SHDoc</a></div>
   </body>
 </html>
+{{- define "section" }}{{ if .Name }}
+      <h3 class="section">{{ .Path }}</h3>{{ end }}{{ end }}
+{{- define "section-desc" }}{{ with . }}
+      <div class="section-desc">{{ template "markup" . }}</div>{{ end }}{{ end }}
+{{ define "tags" }}{{ with .Since }}
+        <div class="tags">Since: {{ . }}</div>{{ end }}{{ if .HasSee }}
+        <div class="tags">See: <span class="mono">{{ range $i, $s := .See }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}</span></div>{{ end }}{{ with .Author }}
+        <div class="tags">Author: {{ . }}</div>{{ end }}{{ end }}
+{{- define "text" }}{{ range . }}{{ if .Link }}<a class="ref" href="#{{ .Link }}"><code>{{ .Text }}</code></a>{{ else if .IsCode }}<code>{{ .Text }}</code>{{ else }}{{ .Text }}{{ end }}{{ end }}{{ end }}
+{{- define "markup" }}{{ range . }}{{ if .IsCode }}<pre>{{ range $i, $l := .Code }}{{ if $i }}
+{{ end }}{{ $l }}{{ end }}</pre>{{ else if .IsParagraph }}<p>{{ template "text" .Text }}</p>{{ else if .IsNumbered }}<ol>{{ range .Items }}<li>{{ template "text" . }}</li>{{ end }}</ol>{{ else }}<ul>{{ range .Items }}<li>{{ template "text" . }}</li>{{ end }}</ul>{{ end }}{{ end }}{{ end }}
@@ -0,0 +1,59 @@
+<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta charset='utf-8'>
+
+    <title>{{ .Title }}</title>
+
+    <link href='https://fonts.googleapis.com/css?family=Roboto:400,300,700|Roboto+Mono' rel='stylesheet' type='text/css'>
+
+    <style type="text/css">
+      html,body { color:#222; font-family:Roboto, Verdana, sans-serif; height:100%; margin:0; padding:0 }
+      h1,h2,h3 { color:#666; font-weight:100; margin:0; padding:32px 0 8px }
+      h1 { border-bottom:1px #DDD solid; font-size:2.2em; padding-bottom:8px }
+      h2 { font-size:1.6em }
+      code,.mono { font-family:'Roboto Mono', monospace }
+      a { color:#222; text-decoration:none }
+      p,div { position:relative }
+      div.doc { display:block; font-size:.9em; margin-left:auto; margin-right:auto; padding-top:32px; width:800px }
+      div.script { margin:0; padding-top:16px }
+      div.undocumented { color:#888; margin:0; padding-top:8px }
+      span.desc { color:#444 }
+      span.stats { color:#999; font-size:.8em }
+      div.footer { color:#999; font-size:.9em; padding:64px 0 40px; text-align:center }
+      div.footer a { border-bottom:1px solid #666; color:#666 }
+    </style>
+  </head>
+  <body>
+    <div class="doc">
+      <h1>{{ .Title }}</h1>
+
+      <!-- SCRIPTS -->
+
+      {{ if .HasScripts }}
+      <h2>Scripts</h2>
+      {{ range .Scripts }}
+      <div class="script">
+        <div>
+          <a class="mono" href="{{ .Link }}">{{ .Path }}</a>{{ with .Summary }}<span class="desc"> — {{ . }}</span>{{ end }}
+        </div>
+        <span class="stats">Constants: {{ len .Document.Constants }} · Variables: {{ len .Document.Variables }} · Methods: {{ len .Document.Methods }}</span>
+      </div>
+      {{ end }}
+      {{ end }}
+
+      <!-- UNDOCUMENTED -->
+
+      {{ if .HasUndocumented }}
+      <h2>Undocumented</h2>
+      {{ range .Undocumented }}
+      <div class="undocumented mono">{{ . }}</div>
+      {{ end }}
+      {{ end }}
+    </div>
+
+    <!-- FOOTER -->
+
+    <div class="footer">Generated with ❤ by <a href="https://kaos.sh/shdoc">SHDoc</a></div>
+  </body>
+</html>
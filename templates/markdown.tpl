@@ -2,24 +2,95 @@
 {{ if .HasAbout }}
 ### About
 
-{{ range .About }}{{ . }}{{ end}}
+{{ template "markup" .AboutMarkup }}
 {{ end }}
 
 {{ if .HasConstants }}
 ### Constants
-{{ range .Constants }}
-* `{{ .Name}} = {{ .Value }}` {{ .UnitedDesc }} (_{{ .TypeName 0 }}_){{ end }}
+{{ range .Groups }}{{ if .HasConstants }}{{ template "section" . }}{{ template "section-desc" ($.MarkupOf .Desc) }}{{ range .Constants }}
+* <a id="{{ $.AnchorOf .File .Line }}"></a>{{ if .IsMultiline }}{{ if .IsDeprecated }}~~`{{ .Name }}`~~{{ else }}`{{ .Name }}`{{ end }} {{ template "text" ($.MarkupOf .Desc).Head }} (_{{ .TypeName 0 }}_)
+```bash
+{{ .Name }}={{ .Value }}
+```{{ else }}{{ if .IsDeprecated }}~~`{{ .Name}} = {{ .Value }}`~~{{ else }}`{{ .Name}} = {{ .Value }}`{{ end }} {{ template "text" ($.MarkupOf .Desc).Head }} (_{{ .TypeName 0 }}_){{ end }}{{ with ($.MarkupOf .Desc).Tail }}{{ template "markup-item" . }}{{ end }}{{ template "variable-tags" . }}{{ if .HasElements }}{{ $isMap := .IsMap }}{{ range .Elements }}
+  * {{ if or $isMap .Key }}`{{ .Key }}` → {{ end }}`{{ .Value }}`{{ end }}{{ end }}{{ end }}{{ end }}{{ end }}
 {{ end }}
 
 {{ if .HasVariables }}
 ### Global Variables
-{{ range .Variables }}
-* `{{ .Name}} = {{ .Value }}` {{ .UnitedDesc }} (_{{ .TypeName 0 }}_){{ end }}
+{{ range .Groups }}{{ if .HasVariables }}{{ template "section" . }}{{ template "section-desc" ($.MarkupOf .Desc) }}{{ range .Variables }}
+* <a id="{{ $.AnchorOf .File .Line }}"></a>{{ if .IsMultiline }}{{ if .IsDeprecated }}~~`{{ .Name }}`~~{{ else }}`{{ .Name }}`{{ end }} {{ template "text" ($.MarkupOf .Desc).Head }} (_{{ .TypeName 0 }}_)
+```bash
+{{ .Name }}={{ .Value }}
+```{{ else }}{{ if .IsDeprecated }}~~`{{ .Name}} = {{ .Value }}`~~{{ else }}`{{ .Name}} = {{ .Value }}`{{ end }} {{ template "text" ($.MarkupOf .Desc).Head }} (_{{ .TypeName 0 }}_){{ end }}{{ with ($.MarkupOf .Desc).Tail }}{{ template "markup-item" . }}{{ end }}{{ template "variable-tags" . }}{{ if .HasElements }}{{ $isMap := .IsMap }}{{ range .Elements }}
+  * {{ if or $isMap .Key }}`{{ .Key }}` → {{ end }}`{{ .Value }}`{{ end }}{{ end }}{{ end }}{{ end }}{{ end }}
 {{ end }}
 
 {{ if .HasMethods }}
 ### Methods
-{{ range .Methods }}
-`{{ .Name }}` - {{ .UnitedDesc }}
-{{ range .Arguments }}* {{ .Index }}: {{ .Desc }} {{ if not .IsUnknown }}(_{{ .TypeName 0 }}_){{ end }}{{ if .IsOptional }} [_Optional_]{{ end }}
-{{ end }}{{ end }}{{ end }}
+{{ range .Groups }}{{ if .HasMethods }}{{ template "section" . }}{{ template "section-desc" ($.MarkupOf .Desc) }}{{ range .Methods }}
+<a id="{{ $.AnchorOf .File .Line }}"></a>{{ if .IsDeprecated }}~~`{{ .Name }}`~~{{ else }}`{{ .Name }}`{{ end }} - {{ template "text" ($.MarkupOf .Desc).Head }}{{ with $.OriginOf .File }} <sub>{{ . }}</sub>{{ end }}
+{{ with ($.MarkupOf .Desc).Tail }}
+{{ template "markup" . }}
+{{ end }}{{ if .HasArguments }}
+```
+{{ .Signature }}
+```
+{{ end }}{{ range .Arguments }}* {{ .Index }}{{ with .Name }} `{{ . }}`{{ end }}: {{ range $i, $l := .DescLines }}{{ if $i }}  
+  {{ end }}{{ $l }}{{ end }} {{ if not .IsUnknown }}(_{{ .TypeName 0 }}_){{ end }}{{ with .Constraints }} `{{ . }}`{{ end }}{{ if .HasDefault }} [_Default:_ `{{ .Default }}`]{{ else if .IsOptional }} [_Optional_]{{ end }}
+{{ end }}{{ if .HasCodes }}
+| Code | Description |
+|------|-------------|
+{{ $m := . }}{{ range .Codes }}| `{{ . }}` | {{ range $i, $l := $m.CodeLines . }}{{ if $i }}<br/>{{ end }}{{ $l }}{{ end }} |
+{{ end }}{{ end }}{{ if .HasEcho }}
+_Echo:_ {{ range $i, $l := .ResultEcho.Desc }}{{ if $i }}  
+{{ end }}{{ $l }}{{ end }}{{ if not .ResultEcho.IsUnknown }} (_{{ .ResultEcho.TypeName 0 }}_){{ end }}
+{{ end }}{{ if .HasStderr }}
+_Stderr:_ {{ range $i, $l := .Stderr }}{{ if $i }}  
+{{ end }}{{ $l }}{{ end }}
+{{ end }}{{ if .HasFiles }}
+_Files:_
+{{ range .Files }}* `{{ .Path }}`{{ with .Desc }} - {{ . }}{{ end }}
+{{ end }}{{ end }}{{ if .HasReads }}
+_Reads:_
+{{ range .Reads }}* `{{ .Name }}`{{ with $.DescOf . }} - {{ . }}{{ end }}
+{{ end }}{{ end }}{{ if .HasModifies }}
+_Modifies:_
+{{ range .Modifies }}* `{{ .Name }}`{{ with $.DescOf . }} - {{ . }}{{ end }}
+{{ end }}{{ end }}{{ template "method-tags" . }}{{ if .HasCalls }}
+_Calls:_ {{ range $i, $c := .Calls }}{{ if $i }}, {{ end }}`{{ $c }}`{{ end }}
+{{ end }}{{ if .HasCallers }}
+_Called by:_ {{ range $i, $c := .CalledBy }}{{ if $i }}, {{ end }}`{{ $c }}`{{ end }}
+{{ end }}{{ end }}{{ end }}{{ end }}{{ end }}
+{{- define "section" }}{{ if .Name }}
+#### {{ .Path }}
+{{ end }}{{ end }}
+{{- define "section-desc" }}{{ with . }}
+{{ template "markup" . }}
+{{ end }}{{ end }}
+{{ define "deprecation" }}_Deprecated{{ with .Replacement }}, use `{{ . }}` instead{{ end }}{{ with .Note }}: {{ . }}{{ end }}_{{ end }}
+{{ define "method-tags" }}{{ if .IsDeprecated }}
+{{ template "deprecation" .Deprecated }}
+{{ end }}{{ with .Since }}
+_Since:_ {{ . }}
+{{ end }}{{ if .HasSee }}
+_See:_ {{ range $i, $s := .See }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}
+{{ end }}{{ with .Author }}
+_Author:_ {{ . }}
+{{ end }}{{ end }}
+{{ define "variable-tags" }}{{ if .IsDeprecated }}
+  * {{ template "deprecation" .Deprecated }}{{ end }}{{ with .Since }}
+  * _Since:_ {{ . }}{{ end }}{{ if .HasSee }}
+  * _See:_ {{ range $i, $s := .See }}{{ if $i }}, {{ end }}{{ $s }}{{ end }}{{ end }}{{ with .Author }}
+  * _Author:_ {{ . }}{{ end }}{{ end }}
+{{- define "text" }}{{ range . }}{{ if .Link }}[`{{ .Text }}`](#{{ .Link }}){{ else if .IsCode }}`{{ .Text }}`{{ else }}{{ .Text }}{{ end }}{{ end }}{{ end }}
+{{- define "markup" }}{{ range $i, $b := . }}{{ if $i }}
+
+{{ end }}{{ if .IsCode }}```
+{{ range .Code }}{{ . }}
+{{ end }}```{{ else if .IsParagraph }}{{ template "text" .Text }}{{ else }}{{ range $j, $t := .Items }}{{ if $j }}
+{{ end }}{{ if $b.IsNumbered }}1.{{ else }}*{{ end }} {{ template "text" $t }}{{ end }}{{ end }}{{ end }}{{ end }}
+{{- define "markup-item" }}{{ range . }}
+{{ if .IsCode }}  ```
+{{ range .Code }}  {{ . }}
+{{ end }}  ```{{ else if .IsParagraph }}  {{ template "text" .Text }}{{ else }}{{ $b := . }}{{ range $j, $t := .Items }}{{ if $j }}
+{{ end }}  {{ if $b.IsNumbered }}1.{{ else }}*{{ end }} {{ template "text" $t }}{{ end }}{{ end }}{{ end }}{{ end }}
@@ -0,0 +1,11 @@
+# {{ .Title }}
+{{ if .HasScripts }}
+### Scripts
+{{ range .Scripts }}
+* [`{{ .Path }}`]({{ .Link }}){{ with .Summary }} - {{ . }}{{ end }}{{ end }}
+{{ end }}
+{{ if .HasUndocumented }}
+### Undocumented
+{{ range .Undocumented }}
+* `{{ . }}`{{ end }}
+{{ end }}